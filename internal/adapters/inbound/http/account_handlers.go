@@ -0,0 +1,141 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleForgotPassword starts a password reset
+// @Summary Request a password reset
+// @Description Emails a single-use reset link valid for one hour. The response is the same whether or not the address is registered.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.ForgotPasswordRequest true "Account email"
+// @Success 202 {object} domain.MessageResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/forgot-password [post]
+func (h *Handler) HandleForgotPassword(c *gin.Context) {
+	var req domain.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	if err := h.accountUseCase.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao solicitar redefinição de senha"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, domain.MessageResponse{
+		Success: true,
+		Message: "Se o e-mail estiver cadastrado, você receberá um link para redefinir a senha",
+	})
+}
+
+// HandleResetPasswordPage serves the form the reset email links to
+// @Summary Password reset page
+// @Description HTML form that posts the token of the link and the new password to POST /auth/reset-password.
+// @Tags auth
+// @Produce html
+// @Param token query string true "Reset token"
+// @Success 200 {string} string "HTML page"
+// @Router /auth/reset-password [get]
+func (h *Handler) HandleResetPasswordPage(c *gin.Context) {
+	// The token is in the URL, so it must not leak through the Referer header or a cache
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.String(http.StatusOK, getResetPasswordHTML())
+}
+
+// HandleResetPassword sets a new password using an emailed token
+// @Summary Reset password
+// @Description Sets a new password with the token from the reset email. All sessions of the account are revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} domain.MessageResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/reset-password [post]
+func (h *Handler) HandleResetPassword(c *gin.Context) {
+	var req domain.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	err := h.accountUseCase.ResetPassword(c.Request.Context(), req.Token, req.Password)
+	h.audit(c, &domain.AuditEvent{Action: domain.AuditActionPasswordReset, Outcome: auditOutcome(err)})
+	if errors.Is(err, domain.ErrInvalidAccountToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao redefinir senha: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.MessageResponse{Success: true, Message: "Senha redefinida com sucesso"})
+}
+
+// HandleVerifyEmail confirms an email address
+// @Summary Verify email
+// @Description Confirms the account email with the token from the verification link.
+// @Tags auth
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} domain.MessageResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/verify-email [get]
+func (h *Handler) HandleVerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Token não informado"})
+		return
+	}
+
+	err := h.accountUseCase.VerifyEmail(c.Request.Context(), token)
+	if errors.Is(err, domain.ErrInvalidAccountToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao confirmar e-mail: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.MessageResponse{Success: true, Message: "E-mail confirmado com sucesso"})
+}
+
+// HandleResendVerification sends a new verification email
+// @Summary Resend verification email
+// @Description Sends a new verification link to the authenticated user, invalidating previous ones. Does nothing if the email is already verified.
+// @Tags auth
+// @Produce json
+// @Success 202 {object} domain.MessageResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me/verify-email [post]
+func (h *Handler) HandleResendVerification(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Usuário não identificado"})
+		return
+	}
+
+	if err := h.accountUseCase.SendVerificationEmail(c.Request.Context(), userID.(int64)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao enviar e-mail de verificação: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, domain.MessageResponse{Success: true, Message: "E-mail de verificação enviado"})
+}
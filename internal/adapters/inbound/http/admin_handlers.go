@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleAdminListUsers lists every registered user
+// @Summary List all users (Admin)
+// @Description Retrieves every registered user. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} domain.ListUsersResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/admin/users [get]
+func (h *Handler) HandleAdminListUsers(c *gin.Context) {
+	users, err := h.userUseCase.ListUsers(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao listar usuários: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"users":   users,
+	})
+}
+
+// HandleAdminUnlockUser clears a user's failed login lockout
+// @Summary Unlock a user account (Admin)
+// @Description Clears the failed login counter and lockout of a user's account. Requires the admin role.
+// @Tags admin
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/admin/users/{id}/unlock [post]
+func (h *Handler) HandleAdminUnlockUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de usuário inválido"})
+		return
+	}
+
+	err = h.userUseCase.UnlockAccount(c.Request.Context(), c.GetInt64("userID"), userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao desbloquear usuário: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleAdminListVideos lists the videos of every user
+// @Summary List all videos (Admin)
+// @Description Retrieves the videos of every user with their processing status. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} domain.ListVideosResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/admin/videos [get]
+func (h *Handler) HandleAdminListVideos(c *gin.Context) {
+	videos, err := h.videoUseCase.ListAllVideos(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao listar vídeos: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"videos":  videos,
+	})
+}
+
+// HandleAdminFailVideo marks a pending or processing video as failed
+// @Summary Force-fail a processing job (Admin)
+// @Description Marks a pending or processing video as FAILED, e.g. when the worker is stuck. Requires the admin role.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Video ID"
+// @Param request body domain.FailVideoRequest false "Failure reason"
+// @Success 200 {object} domain.Video
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/admin/videos/{id}/fail [post]
+func (h *Handler) HandleAdminFailVideo(c *gin.Context) {
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de vídeo inválido"})
+		return
+	}
+
+	var req domain.FailVideoRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+			return
+		}
+	}
+
+	video, err := h.videoUseCase.FailVideo(c.Request.Context(), videoID, req.Reason)
+	h.audit(c, &domain.AuditEvent{
+		Action:  domain.AuditActionVideoFailed,
+		Outcome: auditOutcome(err),
+		Target:  fmt.Sprintf("video:%d", videoID),
+		Details: map[string]string{"reason": req.Reason},
+	})
+	switch {
+	case errors.Is(err, domain.ErrVideoNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	case errors.Is(err, domain.ErrVideoFinished):
+		c.JSON(http.StatusConflict, gin.H{"success": false, "message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao atualizar vídeo: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, video)
+}
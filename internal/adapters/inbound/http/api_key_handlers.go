@@ -0,0 +1,130 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleCreateAPIKey creates an API key for the authenticated user
+// @Summary Create an API key
+// @Description Creates an API key for machine-to-machine access. The key is only returned in this response; store it safely.
+// @Description Available scopes: videos:read, videos:write and admin (admins only). Defaults to videos:read and videos:write.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateAPIKeyRequest true "Key data"
+// @Success 201 {object} domain.CreateAPIKeyResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/keys [post]
+func (h *Handler) HandleCreateAPIKey(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Usuário não identificado"})
+		return
+	}
+
+	var req domain.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	response, err := h.apiKeyUseCase.Create(c.Request.Context(), userID.(int64), req.Name, req.Scopes, req.ExpiresAt)
+	event := &domain.AuditEvent{Action: domain.AuditActionAPIKeyCreated, Outcome: auditOutcome(err)}
+	if err == nil {
+		event.Target = fmt.Sprintf("api_key:%d", response.APIKey.ID)
+	}
+	h.audit(c, event)
+	switch {
+	case errors.Is(err, domain.ErrInvalidAPIKeyName), errors.Is(err, domain.ErrInvalidAPIKeyExpiry), errors.Is(err, domain.ErrInvalidScope):
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao criar chave de API"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// HandleListAPIKeys lists the API keys of the authenticated user
+// @Summary List API keys
+// @Description Lists the user's API keys, including revoked and expired ones. Secrets are never returned.
+// @Tags api-keys
+// @Produce json
+// @Success 200 {object} domain.ListAPIKeysResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/keys [get]
+func (h *Handler) HandleListAPIKeys(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Usuário não identificado"})
+		return
+	}
+
+	keys, err := h.apiKeyUseCase.List(c.Request.Context(), userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao listar chaves: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"keys":    keys,
+	})
+}
+
+// HandleRevokeAPIKey revokes one of the authenticated user's API keys
+// @Summary Revoke an API key
+// @Description Revokes an API key immediately.
+// @Tags api-keys
+// @Param id path int true "API key ID"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/keys/{id} [delete]
+func (h *Handler) HandleRevokeAPIKey(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Usuário não identificado"})
+		return
+	}
+
+	keyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de chave inválido"})
+		return
+	}
+
+	err = h.apiKeyUseCase.Revoke(c.Request.Context(), userID.(int64), keyID)
+	h.audit(c, &domain.AuditEvent{Action: domain.AuditActionAPIKeyRevoked, Outcome: auditOutcome(err), Target: fmt.Sprintf("api_key:%d", keyID)})
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao revogar chave: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
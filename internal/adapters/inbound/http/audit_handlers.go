@@ -0,0 +1,130 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// audit records a security event with the details of the current request. The actor defaults
+// to the authenticated user. A failure is logged and never fails the request.
+func (h *Handler) audit(c *gin.Context, event *domain.AuditEvent) {
+	if event.ActorID == nil {
+		if userID, ok := c.Get("userID"); ok {
+			id := userID.(int64)
+			event.ActorID = &id
+		}
+	}
+	event.IP = c.ClientIP()
+	event.UserAgent = c.Request.UserAgent()
+	event.RequestID = c.GetString("requestID")
+
+	// Recorded even if the client has already disconnected
+	if err := h.auditUseCase.Record(context.WithoutCancel(c.Request.Context()), event); err != nil {
+		fmt.Printf("⚠️ Erro ao registrar evento de auditoria %s: %v\n", event.Action, err)
+	}
+}
+
+// auditOutcome maps an operation error to the outcome recorded for it
+func auditOutcome(err error) string {
+	if err != nil {
+		return domain.AuditOutcomeFailure
+	}
+	return domain.AuditOutcomeSuccess
+}
+
+// HandleAdminListAuditEvents queries the security audit log
+// @Summary Query the audit log
+// @Description Lists audit events, newest first. All filters are optional and combined.
+// @Tags admin
+// @Produce json
+// @Param action query string false "Action, e.g. auth.login"
+// @Param outcome query string false "success, failure or denied"
+// @Param actor_id query int false "User who performed the action"
+// @Param target query string false "Target, e.g. video:42"
+// @Param ip query string false "Client IP"
+// @Param request_id query string false "Request ID (X-Request-ID)"
+// @Param from query string false "Start time, RFC 3339 (inclusive)"
+// @Param to query string false "End time, RFC 3339 (exclusive)"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param offset query int false "Events to skip"
+// @Success 200 {object} domain.ListAuditEventsResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/admin/audit [get]
+func (h *Handler) HandleAdminListAuditEvents(c *gin.Context) {
+	filter := domain.AuditFilter{
+		Action:    c.Query("action"),
+		Outcome:   c.Query("outcome"),
+		Target:    c.Query("target"),
+		IP:        c.Query("ip"),
+		RequestID: c.Query("request_id"),
+	}
+
+	var err error
+	if value := c.Query("actor_id"); value != "" {
+		var actorID int64
+		if actorID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "actor_id inválido"})
+			return
+		}
+		filter.ActorID = &actorID
+	}
+	for name, dest := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": name + " inválido, use o formato RFC 3339"})
+				return
+			}
+			*dest = &t
+		}
+	}
+	for name, dest := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if value := c.Query(name); value != "" {
+			if *dest, err = strconv.Atoi(value); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": name + " inválido"})
+				return
+			}
+		}
+	}
+
+	events, err := h.auditUseCase.Query(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao consultar auditoria: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"events":  events,
+	})
+}
+
+// auditLogin records a sign-in attempt. Lockouts are recorded as denied.
+func (h *Handler) auditLogin(c *gin.Context, action, target string, response domain.AuthResponse, err error) {
+	event := &domain.AuditEvent{Action: action, Outcome: auditOutcome(err), Target: target}
+	var locked *domain.LoginLockedError
+	switch {
+	case errors.As(err, &locked):
+		event.Outcome = domain.AuditOutcomeDenied
+	case err != nil:
+		event.Details = map[string]string{"reason": err.Error()}
+	case response.MFARequired:
+		// Only the password step succeeded; the session starts at /auth/mfa/verify
+		event.ActorID = &response.User.ID
+		event.Details = map[string]string{"mfa": "required"}
+	default:
+		event.ActorID = &response.User.ID
+	}
+	h.audit(c, event)
+}
@@ -1,59 +1,123 @@
 package http
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strings"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// AuthMiddleware accepts either a JWT access token ("Authorization: Bearer <jwt>") or an
+// API key, sent as "X-API-Key: <key>" or "Authorization: Bearer <key>".
+func AuthMiddleware(users ports.UserUseCase, apiKeys ports.APIKeyUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
-			return
+		credential := c.GetHeader("X-API-Key")
+		if credential == "" {
+			authHeader := c.GetHeader("Authorization")
+			if authHeader == "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+				c.Abort()
+				return
+			}
+
+			parts := strings.Split(authHeader, " ")
+			if len(parts) != 2 || parts[0] != "Bearer" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+				c.Abort()
+				return
+			}
+			credential = parts[1]
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+		var principal *domain.Principal
+		var err error
+		if strings.HasPrefix(credential, domain.APIKeyMarker) {
+			principal, err = apiKeys.Authenticate(c.Request.Context(), credential)
+		} else {
+			principal, err = users.ValidateToken(c.Request.Context(), credential)
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrSessionRevoked):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		case errors.Is(err, domain.ErrInvalidAPIKey):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid, expired or revoked API key"})
+			c.Abort()
+			return
+		case err != nil:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			c.Abort()
 			return
 		}
 
-		tokenString := parts[1]
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		c.Set("principal", principal)
+		c.Set("userID", principal.UserID)
+		c.Set("role", principal.Role)
+		c.Set("sessionID", principal.SessionID)
+		c.Next()
+	}
+}
+
+// RequireRole must run after AuthMiddleware and only lets through users holding one of the given roles
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
 			}
-			return []byte(jwtSecret), nil
-		})
+		}
 
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
+// RequireScope must run after AuthMiddleware. Sessions always pass; API keys need the scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := c.MustGet("principal").(*domain.Principal)
+		if !ok || !principal.HasScope(scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "API key is missing the " + scope + " scope"})
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+// RequireSession must run after AuthMiddleware and rejects API keys, e.g. so a leaked key cannot mint new keys
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("sessionID") == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This operation requires a logged-in session"})
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		userID, ok := claims["sub"].(float64)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
+// RequireVerifiedEmail must run after AuthMiddleware and blocks accounts whose email is not confirmed
+func RequireVerifiedEmail(accounts ports.AccountUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		verified, err := accounts.IsEmailVerified(c.Request.Context(), c.GetInt64("userID"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not check email verification"})
+			c.Abort()
+			return
+		}
+		if !verified {
+			c.JSON(http.StatusForbidden, gin.H{"error": domain.ErrEmailNotVerified.Error()})
 			c.Abort()
 			return
 		}
-
-		c.Set("userID", int64(userID))
 		c.Next()
 	}
 }
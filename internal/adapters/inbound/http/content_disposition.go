@@ -0,0 +1,57 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// attachmentDisposition builds a Content-Disposition header value following RFC 6266.
+// Old clients get an ASCII-only "filename" fallback, while modern ones use the
+// RFC 5987 "filename*" parameter carrying the original UTF-8 name.
+func attachmentDisposition(filename string) string {
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, asciiFallback(filename), encodeRFC5987(filename))
+}
+
+func asciiFallback(filename string) string {
+	var b strings.Builder
+	for _, r := range filename {
+		switch {
+		case r == '"' || r == '\\' || r == '/':
+			b.WriteByte('_')
+		case r < 0x20 || r == 0x7f || r >= utf8.RuneSelf:
+			b.WriteByte('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "download"
+	}
+	return b.String()
+}
+
+// encodeRFC5987 percent-encodes every byte that is not an attr-char (RFC 5987, section 3.2.1)
+func encodeRFC5987(value string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isAttrChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+func isAttrChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		want     string
+	}{
+		{"plain ascii", "frames.zip", `attachment; filename="frames.zip"; filename*=UTF-8''frames.zip`},
+		{"spaces", "my frames.zip", `attachment; filename="my frames.zip"; filename*=UTF-8''my%20frames.zip`},
+		{"quotes and backslashes", `say "hi"\now.zip`, `attachment; filename="say _hi__now.zip"; filename*=UTF-8''say%20%22hi%22%5Cnow.zip`},
+		{"path separators", "../secret.zip", `attachment; filename=".._secret.zip"; filename*=UTF-8''..%2Fsecret.zip`},
+		{"header injection", "clip.zip\r\nSet-Cookie: a=b", `attachment; filename="clip.zip__Set-Cookie: a=b"; filename*=UTF-8''clip.zip%0D%0ASet-Cookie%3A%20a%3Db`},
+		{"accented", "vídeo ação.zip", `attachment; filename="v_deo a__o.zip"; filename*=UTF-8''v%C3%ADdeo%20a%C3%A7%C3%A3o.zip`},
+		{"emoji", "🎬 cena.zip", `attachment; filename="_ cena.zip"; filename*=UTF-8''%F0%9F%8E%AC%20cena.zip`},
+		{"empty", "", `attachment; filename="download"; filename*=UTF-8''`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := attachmentDisposition(tt.filename)
+
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, got, "\r")
+			assert.NotContains(t, got, "\n")
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig is the cross-origin policy for browser clients
+type CORSConfig struct {
+	// AllowedOrigins are exact origins ("https://app.example.com"), patterns with one
+	// wildcard ("https://*.example.com") or "*" for any origin
+	AllowedOrigins []string
+	AllowedMethods []string
+	// AllowedHeaders may contain "*" to accept whatever the preflight asks for
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration // How long browsers may cache a preflight response
+}
+
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
+		AllowedHeaders: []string{"Authorization", "Content-Type", "X-API-Key", requestIDHeader},
+		ExposedHeaders: []string{"Content-Disposition", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining",
+			"RateLimit-Reset", "RateLimit-Policy", requestIDHeader},
+		MaxAge: 12 * time.Hour,
+	}
+}
+
+// CORS returns the middleware enforcing cfg. Preflight requests are answered directly;
+// preflights from origins that are not allowed get 403.
+func CORS(cfg CORSConfig) (gin.HandlerFunc, error) {
+	allowAny := false
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+		} else if strings.Count(origin, "*") > 1 {
+			return nil, errors.New("CORS: origem com mais de um curinga: " + origin)
+		}
+	}
+	// Browsers reject credentials with a wildcard origin, and echoing any origin instead
+	// would let every site make authenticated requests
+	if allowAny && cfg.AllowCredentials {
+		return nil, errors.New("CORS: credenciais não podem ser usadas com a origem \"*\"")
+	}
+
+	allowAnyHeader := false
+	for _, header := range cfg.AllowedHeaders {
+		if header == "*" {
+			allowAnyHeader = true
+		}
+	}
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		if !allowAny && !originAllowed(cfg.AllowedOrigins, origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if allowAny {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposed != "" {
+				c.Header("Access-Control-Expose-Headers", exposed)
+			}
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		c.Header("Access-Control-Allow-Methods", methods)
+		if allowAnyHeader {
+			c.Header("Access-Control-Allow-Headers", c.GetHeader("Access-Control-Request-Headers"))
+		} else {
+			c.Header("Access-Control-Allow-Headers", headers)
+		}
+		if cfg.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}, nil
+}
+
+// originAllowed matches origin against exact entries and single-wildcard patterns.
+// Comparison is case-insensitive, as scheme and host are.
+func originAllowed(allowed []string, origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		prefix, suffix, wildcard := strings.Cut(pattern, "*")
+		if !wildcard {
+			if pattern == origin {
+				return true
+			}
+			continue
+		}
+		if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
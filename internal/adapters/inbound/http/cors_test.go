@@ -0,0 +1,151 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCORSRouter(t *testing.T, cfg CORSConfig) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cors, err := CORS(cfg)
+	require.NoError(t, err)
+	r := gin.New()
+	r.Use(cors)
+	r.GET("/resource", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func corsRequest(r *gin.Engine, method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/resource", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func preflight(r *gin.Engine, origin string) *httptest.ResponseRecorder {
+	return corsRequest(r, http.MethodOptions, origin, map[string]string{
+		"Access-Control-Request-Method":  http.MethodPost,
+		"Access-Control-Request-Headers": "Authorization, X-Custom",
+	})
+}
+
+func TestOriginAllowed(t *testing.T) {
+	allowed := []string{"https://app.example.com", "https://*.example.com", "http://localhost:*"}
+
+	for origin, want := range map[string]bool{
+		"https://app.example.com":     true,
+		"HTTPS://App.Example.com":     true,
+		"https://a.b.example.com":     true,
+		"http://localhost:3000":       true,
+		"https://example.com":         false, // The wildcard needs a subdomain
+		"https://.example.com":        false,
+		"https://evilexample.com":     false,
+		"https://example.com.evil.io": false,
+		"http://app.example.com":      false,
+		"http://localhost":            false,
+	} {
+		assert.Equal(t, want, originAllowed(allowed, origin), origin)
+	}
+}
+
+func TestCORS_Config(t *testing.T) {
+	t.Run("credentials with any origin are refused", func(t *testing.T) {
+		cfg := DefaultCORSConfig()
+		cfg.AllowCredentials = true
+
+		_, err := CORS(cfg)
+
+		assert.ErrorContains(t, err, "credenciais")
+	})
+
+	t.Run("pattern with more than one wildcard is refused", func(t *testing.T) {
+		cfg := DefaultCORSConfig()
+		cfg.AllowedOrigins = []string{"https://*.*.example.com"}
+
+		_, err := CORS(cfg)
+
+		assert.ErrorContains(t, err, "curinga")
+	})
+}
+
+func TestCORS(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.AllowedOrigins = []string{"https://*.example.com"}
+	cfg.AllowCredentials = true
+	cfg.MaxAge = time.Hour
+	r := newCORSRouter(t, cfg)
+
+	t.Run("preflight from an allowed origin", func(t *testing.T) {
+		w := preflight(r, "https://app.example.com")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "GET, POST, PUT, PATCH, DELETE, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization, Content-Type, X-API-Key, X-Request-ID", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "3600", w.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, w.Header().Values("Vary"))
+	})
+
+	t.Run("preflight from another origin is forbidden", func(t *testing.T) {
+		for _, origin := range []string{"https://example.com", "https://evil-example.com", "https://app.example.com.evil.io"} {
+			w := preflight(r, origin)
+
+			assert.Equal(t, http.StatusForbidden, w.Code, origin)
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"), origin)
+			assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"), origin)
+		}
+	})
+
+	t.Run("request from an allowed origin exposes headers", func(t *testing.T) {
+		w := corsRequest(r, http.MethodGet, "https://app.example.com", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "Retry-After")
+		assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "X-Request-ID")
+		assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+	})
+
+	t.Run("request from another origin is served without CORS headers", func(t *testing.T) {
+		w := corsRequest(r, http.MethodGet, "https://evil.io", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Expose-Headers"))
+	})
+
+	t.Run("request without Origin is left alone", func(t *testing.T) {
+		w := corsRequest(r, http.MethodGet, "", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Values("Vary"))
+	})
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.AllowedHeaders = []string{"*"}
+	r := newCORSRouter(t, cfg)
+
+	w := preflight(r, "https://anywhere.io")
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	// "*" echoes what the preflight asks for
+	assert.Equal(t, "Authorization, X-Custom", w.Header().Get("Access-Control-Allow-Headers"))
+}
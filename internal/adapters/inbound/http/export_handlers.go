@@ -0,0 +1,101 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportDownloadName is the file name offered for a personal data archive
+const exportDownloadName = "dados_fiapx.zip"
+
+// HandleRequestExport starts a copy of the authenticated user's personal data
+// @Summary Request a copy of my data
+// @Description Builds a ZIP with the profile, videos and their status history in the background. A download link valid for 48 hours is emailed when it is ready. A request already in progress is returned instead of starting another.
+// @Tags profile
+// @Produce json
+// @Success 202 {object} domain.DataExport
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me/export [post]
+func (h *Handler) HandleRequestExport(c *gin.Context) {
+	export, err := h.dataExportUseCase.RequestExport(c.Request.Context(), c.GetInt64("userID"))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao solicitar exportação: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+// HandleGetExport reports the progress of one of the user's data exports
+// @Summary Get a data export
+// @Tags profile
+// @Produce json
+// @Param id path int true "Export ID"
+// @Success 200 {object} domain.DataExport
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me/exports/{id} [get]
+func (h *Handler) HandleGetExport(c *gin.Context) {
+	exportID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de exportação inválido"})
+		return
+	}
+
+	export, err := h.dataExportUseCase.GetExport(c.Request.Context(), c.GetInt64("userID"), exportID)
+	if errors.Is(err, domain.ErrExportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao buscar exportação: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
+
+// HandleExportDownload serves a personal data archive from the emailed link
+// @Summary Download a data export
+// @Description The token comes from the link emailed when the export is ready and works until the archive expires.
+// @Tags profile
+// @Param token query string true "Download token"
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /exports/download [get]
+func (h *Handler) HandleExportDownload(c *gin.Context) {
+	path, err := h.dataExportUseCase.OpenDownload(c.Request.Context(), c.Query("token"))
+	h.audit(c, &domain.AuditEvent{Action: domain.AuditActionExportDownload, Outcome: auditOutcome(err)})
+	if errors.Is(err, domain.ErrExportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao buscar exportação: " + err.Error()})
+		return
+	}
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Header("Content-Disposition", attachmentDisposition(exportDownloadName))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Cache-Control", "no-store")
+
+	c.File(path)
+}
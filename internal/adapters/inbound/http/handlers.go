@@ -1,8 +1,12 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"video-processor/internal/core/domain"
 	"video-processor/internal/core/ports"
 
@@ -10,42 +14,188 @@ import (
 )
 
 type Handler struct {
-	videoUseCase ports.VideoUseCase
-	userUseCase  ports.UserUseCase
-	storage      ports.Storage
-	jwtSecret    string
+	videoUseCase   ports.VideoUseCase
+	userUseCase    ports.UserUseCase
+	apiKeyUseCase  ports.APIKeyUseCase
+	ssoUseCase     ports.SSOUseCase // nil when single sign-on is not configured
+	accountUseCase ports.AccountUseCase
+	mfaUseCase     ports.MFAUseCase
+	profileUseCase ports.ProfileUseCase
+	tokenSigner    ports.TokenSigner
+	storage        ports.Storage
+
+	dataExportUseCase   ports.DataExportUseCase
+	organizationUseCase ports.OrganizationUseCase
+	auditUseCase        ports.AuditUseCase
+	healthUseCase       ports.HealthUseCase
+
+	requireVerifiedEmail bool
+	rateLimiter          ports.RateLimiter // nil disables rate limiting
+	rateLimits           domain.RateLimitPolicies
 }
 
-func NewHandler(v ports.VideoUseCase, u ports.UserUseCase, s ports.Storage, jwtSecret string) *Handler {
+func NewHandler(v ports.VideoUseCase, u ports.UserUseCase, k ports.APIKeyUseCase, sso ports.SSOUseCase, a ports.AccountUseCase, m ports.MFAUseCase, p ports.ProfileUseCase, e ports.DataExportUseCase, o ports.OrganizationUseCase, au ports.AuditUseCase, hc ports.HealthUseCase, t ports.TokenSigner, s ports.Storage) *Handler {
 	return &Handler{
-		videoUseCase: v,
-		userUseCase:  u,
-		storage:      s,
-		jwtSecret:    jwtSecret,
+		videoUseCase:   v,
+		userUseCase:    u,
+		apiKeyUseCase:  k,
+		ssoUseCase:     sso,
+		accountUseCase: a,
+		mfaUseCase:     m,
+		profileUseCase: p,
+		tokenSigner:    t,
+		storage:        s,
+
+		dataExportUseCase:   e,
+		organizationUseCase: o,
+		auditUseCase:        au,
+		healthUseCase:       hc,
 	}
 }
 
+// RequireVerifiedEmailForUploads makes uploads available only after the user confirms their email.
+// Must be called before RegisterRoutes.
+func (h *Handler) RequireVerifiedEmailForUploads(required bool) {
+	h.requireVerifiedEmail = required
+}
+
+// EnableRateLimiting applies the given per-route policies using limiter.
+// Must be called before RegisterRoutes.
+func (h *Handler) EnableRateLimiting(limiter ports.RateLimiter, policies domain.RateLimitPolicies) {
+	h.rateLimiter = limiter
+	h.rateLimits = policies
+}
+
+// rateLimit returns the middleware for a policy, or a pass-through when rate limiting is off
+func (h *Handler) rateLimit(policy domain.RateLimitPolicy) gin.HandlerFunc {
+	if h.rateLimiter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return RateLimit(h.rateLimiter, policy)
+}
+
 func (h *Handler) RegisterRoutes(r *gin.Engine) {
 	fmt.Println("Registering routes...")
 	r.GET("/", h.HandleIndex)
+	fmt.Println("Registering: GET /healthz")
+	r.GET("/healthz", h.HandleLiveness)
+	fmt.Println("Registering: GET /readyz")
+	r.GET("/readyz", h.HandleReadiness)
 
 	// Protected routes
 	auth := r.Group("/api")
-	auth.Use(AuthMiddleware(h.jwtSecret))
+	auth.Use(AuthMiddleware(h.userUseCase, h.apiKeyUseCase), h.rateLimit(h.rateLimits.API))
 	{
+		uploadChain := []gin.HandlerFunc{RequireScope(domain.ScopeVideosWrite), h.rateLimit(h.rateLimits.Upload)}
+		if h.requireVerifiedEmail {
+			uploadChain = append(uploadChain, RequireVerifiedEmail(h.accountUseCase))
+		}
 		fmt.Println("Registering: POST /api/upload")
-		auth.POST("/upload", h.HandleVideoUpload)
+		auth.POST("/upload", append(uploadChain, h.HandleVideoUpload)...)
 		fmt.Println("Registering: GET /api/videos")
-		auth.GET("/videos", h.HandleListUserVideos)
+		auth.GET("/videos", RequireScope(domain.ScopeVideosRead), h.HandleListUserVideos)
+		fmt.Println("Registering: GET /api/videos/:id/download")
+		auth.GET("/videos/:id/download", RequireScope(domain.ScopeVideosRead), h.rateLimit(h.rateLimits.Download), h.HandleVideoDownload)
 		fmt.Println("Registering: GET /api/status")
-		auth.GET("/status", h.HandleStatus) // Legacy or general status
+		auth.GET("/status", RequireRole(domain.RoleAdmin), RequireScope(domain.ScopeAdmin), h.HandleStatus) // Legacy or general status
+	}
+
+	// Account settings, only from an interactive session
+	me := auth.Group("/me")
+	me.Use(RequireSession())
+	{
+		fmt.Println("Registering: GET /api/me")
+		me.GET("", h.HandleGetProfile)
+		fmt.Println("Registering: PATCH /api/me")
+		me.PATCH("", h.HandleUpdateProfile)
+		fmt.Println("Registering: DELETE /api/me")
+		me.DELETE("", h.HandleDeleteAccount)
+		fmt.Println("Registering: POST /api/me/password")
+		me.POST("/password", h.HandleChangePassword)
+		fmt.Println("Registering: POST /api/me/verify-email")
+		me.POST("/verify-email", h.HandleResendVerification)
+		fmt.Println("Registering: POST /api/me/mfa/enroll")
+		me.POST("/mfa/enroll", h.HandleMFAEnroll)
+		fmt.Println("Registering: POST /api/me/mfa/confirm")
+		me.POST("/mfa/confirm", h.HandleMFAConfirm)
+		fmt.Println("Registering: DELETE /api/me/mfa")
+		me.DELETE("/mfa", h.HandleMFADisable)
+		fmt.Println("Registering: POST /api/me/export")
+		me.POST("/export", h.HandleRequestExport)
+		fmt.Println("Registering: GET /api/me/exports/:id")
+		me.GET("/exports/:id", h.HandleGetExport)
+	}
+
+	// API key management, only from an interactive session
+	keys := auth.Group("/keys")
+	keys.Use(RequireSession())
+	{
+		fmt.Println("Registering: POST /api/keys")
+		keys.POST("", h.HandleCreateAPIKey)
+		fmt.Println("Registering: GET /api/keys")
+		keys.GET("", h.HandleListAPIKeys)
+		fmt.Println("Registering: DELETE /api/keys/:id")
+		keys.DELETE("/:id", h.HandleRevokeAPIKey)
+	}
+
+	// Organization management, only from an interactive session
+	orgs := auth.Group("/organizations")
+	orgs.Use(RequireSession())
+	{
+		fmt.Println("Registering: POST /api/organizations")
+		orgs.POST("", h.HandleCreateOrganization)
+		fmt.Println("Registering: GET /api/organizations")
+		orgs.GET("", h.HandleListOrganizations)
+		fmt.Println("Registering: GET /api/organizations/:id/members")
+		orgs.GET("/:id/members", h.HandleListMembers)
+		fmt.Println("Registering: POST /api/organizations/:id/members")
+		orgs.POST("/:id/members", h.HandleAddMember)
+		fmt.Println("Registering: PATCH /api/organizations/:id/members/:userId")
+		orgs.PATCH("/:id/members/:userId", h.HandleUpdateMemberRole)
+		fmt.Println("Registering: DELETE /api/organizations/:id/members/:userId")
+		orgs.DELETE("/:id/members/:userId", h.HandleRemoveMember)
 	}
 
-	r.GET("/download/:filename", h.HandleDownload)
+	// Admin routes
+	admin := auth.Group("/admin")
+	admin.Use(RequireRole(domain.RoleAdmin), RequireScope(domain.ScopeAdmin))
+	{
+		fmt.Println("Registering: GET /api/admin/users")
+		admin.GET("/users", h.HandleAdminListUsers)
+		fmt.Println("Registering: POST /api/admin/users/:id/unlock")
+		admin.POST("/users/:id/unlock", h.HandleAdminUnlockUser)
+		fmt.Println("Registering: GET /api/admin/videos")
+		admin.GET("/videos", h.HandleAdminListVideos)
+		fmt.Println("Registering: POST /api/admin/videos/:id/fail")
+		admin.POST("/videos/:id/fail", h.HandleAdminFailVideo)
+		fmt.Println("Registering: GET /api/admin/audit")
+		admin.GET("/audit", h.HandleAdminListAuditEvents)
+		fmt.Println("Registering: GET /api/admin/files/:filename")
+		admin.GET("/files/:filename", h.HandleDownload)
+	}
+
+	downloadLimit := h.rateLimit(h.rateLimits.Download)
+	r.GET("/exports/download", downloadLimit, h.HandleExportDownload)
+
+	// Auth routes, sharing one budget per client IP
+	authLimit := h.rateLimit(h.rateLimits.Auth)
+	r.POST("/register", authLimit, h.HandleRegister)
+	r.POST("/login", authLimit, h.HandleLogin)
+	r.POST("/auth/mfa/verify", authLimit, h.HandleVerifyMFA)
+	r.POST("/auth/refresh", authLimit, h.HandleRefresh)
+	r.POST("/auth/logout", h.HandleLogout)
+	r.POST("/auth/forgot-password", authLimit, h.HandleForgotPassword)
+	r.GET("/auth/reset-password", authLimit, h.HandleResetPasswordPage)
+	r.POST("/auth/reset-password", authLimit, h.HandleResetPassword)
+	r.GET("/auth/verify-email", authLimit, h.HandleVerifyEmail)
+	r.GET("/.well-known/jwks.json", h.HandleJWKS)
 
-	// Auth routes
-	r.POST("/register", h.HandleRegister)
-	r.POST("/login", h.HandleLogin)
+	if h.ssoUseCase != nil {
+		fmt.Println("Registering: GET /auth/oidc/login")
+		r.GET("/auth/oidc/login", authLimit, h.HandleOIDCLogin)
+		fmt.Println("Registering: GET /auth/oidc/callback")
+		r.GET("/auth/oidc/callback", authLimit, h.HandleOIDCCallback)
+	}
 }
 
 func (h *Handler) HandleIndex(c *gin.Context) {
@@ -60,9 +210,12 @@ func (h *Handler) HandleIndex(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce json
 // @Param video formData file true "Video file"
+// @Param organization_id formData int false "Organization that will own the video (editor or owner role required)"
 // @Success 200 {object} domain.ProcessingResult
 // @Failure 400 {object} domain.ProcessingResult
 // @Failure 401 {object} domain.ProcessingResult
+// @Failure 403 {object} domain.ProcessingResult
+// @Failure 404 {object} domain.ProcessingResult
 // @Failure 500 {object} domain.ProcessingResult
 // @Security ApiKeyAuth
 // @Router /api/upload [post]
@@ -80,8 +233,45 @@ func (h *Handler) HandleVideoUpload(c *gin.Context) {
 	}
 	defer file.Close()
 
-	result, err := h.videoUseCase.UploadAndProcess(userID.(int64), header.Filename, file)
-	if err != nil {
+	var organizationID *int64
+	if value := c.PostForm("organization_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de organização inválido"})
+			return
+		}
+		organizationID = &id
+	}
+
+	result, err := h.videoUseCase.UploadAndProcess(c.Request.Context(), userID.(int64), organizationID, header.Filename, file)
+	event := &domain.AuditEvent{
+		Action:  domain.AuditActionVideoUpload,
+		Outcome: auditOutcome(err),
+		Details: map[string]string{"filename": header.Filename},
+	}
+	if result.VideoID != 0 {
+		event.Target = fmt.Sprintf("video:%d", result.VideoID)
+	}
+	if organizationID != nil {
+		event.Details["organization_id"] = strconv.FormatInt(*organizationID, 10)
+	}
+	switch {
+	case errors.Is(err, domain.ErrOrgPermissionDenied), errors.Is(err, domain.ErrOrganizationNotFound):
+		event.Outcome = domain.AuditOutcomeDenied
+	case err == nil && !result.Success:
+		event.Outcome = domain.AuditOutcomeFailure
+		event.Details["error_code"] = result.ErrorCode
+	}
+	h.audit(c, event)
+
+	switch {
+	case errors.Is(err, domain.ErrOrgPermissionDenied):
+		c.JSON(http.StatusForbidden, result)
+		return
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, result)
+		return
+	case err != nil:
 		c.JSON(http.StatusInternalServerError, result)
 		return
 	}
@@ -91,7 +281,7 @@ func (h *Handler) HandleVideoUpload(c *gin.Context) {
 
 // HandleListUserVideos lists all videos for the authenticated user
 // @Summary List user videos
-// @Description Retrieves a list of all videos uploaded by the authenticated user.
+// @Description Retrieves the authenticated user's personal videos and the videos of every organization they belong to.
 // @Tags videos
 // @Produce json
 // @Success 200 {object} domain.ListVideosResponse
@@ -106,7 +296,7 @@ func (h *Handler) HandleListUserVideos(c *gin.Context) {
 		return
 	}
 
-	videos, err := h.videoUseCase.GetVideosByUserID(userID.(int64))
+	videos, err := h.videoUseCase.GetVideosByUserID(c.Request.Context(), userID.(int64))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao listar vídeos: " + err.Error()})
 		return
@@ -118,37 +308,101 @@ func (h *Handler) HandleListUserVideos(c *gin.Context) {
 	})
 }
 
-// HandleDownload serves the processed ZIP file
-// @Summary Download processed video
-// @Description Downloads the ZIP file containing extracted frames for a processed video.
-// @Tags videos
+// HandleDownload serves any processed ZIP file, as listed by HandleStatus (Admin)
+// @Summary Download a processed file
+// @Description Downloads a ZIP file of extracted frames by its name in the output storage.
+// @Tags admin
 // @Param filename path string true "ZIP filename"
 // @Produce application/zip
 // @Success 200 {file} file
-// @Router /download/{filename} [get]
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/admin/files/{filename} [get]
 func (h *Handler) HandleDownload(c *gin.Context) {
-	filename := c.Param("filename")
+	filename := filepath.Base(c.Param("filename"))
 	filePath := h.storage.GetOutputPath(filename)
+	h.audit(c, &domain.AuditEvent{Action: domain.AuditActionFileDownload, Target: "file:" + filename})
 
 	c.Header("Content-Description", "File Transfer")
 	c.Header("Content-Transfer-Encoding", "binary")
-	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Disposition", attachmentDisposition(filename))
 	c.Header("Content-Type", "application/zip")
 
 	c.File(filePath)
 }
 
+// HandleVideoDownload serves the processed ZIP of one of the user's videos
+// @Summary Download a processed video by ID
+// @Description Downloads the ZIP with the extracted frames, named after the original video file.
+// @Tags videos
+// @Param id path int true "Video ID"
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/videos/{id}/download [get]
+func (h *Handler) HandleVideoDownload(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Usuário não identificado"})
+		return
+	}
+
+	videoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de vídeo inválido"})
+		return
+	}
+
+	video, err := h.videoUseCase.GetUserVideo(c.Request.Context(), userID.(int64), videoID)
+	target := fmt.Sprintf("video:%d", videoID)
+	if errors.Is(err, domain.ErrVideoNotFound) {
+		h.audit(c, &domain.AuditEvent{Action: domain.AuditActionVideoDownload, Outcome: domain.AuditOutcomeDenied, Target: target})
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao buscar vídeo: " + err.Error()})
+		return
+	}
+
+	if video.Status != domain.StatusCompleted || video.ZipPath == "" {
+		c.JSON(http.StatusConflict, gin.H{"success": false, "message": domain.ErrVideoNotProcessed.Error()})
+		return
+	}
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Header("Content-Disposition", attachmentDisposition(zipDownloadName(video.OriginalFilename)))
+	c.Header("Content-Type", "application/zip")
+
+	h.audit(c, &domain.AuditEvent{Action: domain.AuditActionVideoDownload, Target: target})
+
+	c.File(h.storage.GetOutputPath(filepath.Base(video.ZipPath)))
+}
+
+// zipDownloadName names the frames archive after the original video, e.g. "férias.mp4" -> "férias.zip"
+func zipDownloadName(originalFilename string) string {
+	return strings.TrimSuffix(originalFilename, filepath.Ext(originalFilename)) + ".zip"
+}
+
 // HandleStatus lists all processed files (Legacy/Admin)
 // @Summary List all processed files
 // @Description Retrieves a list of all processed ZIP files.
 // @Tags videos
 // @Produce json
 // @Success 200 {object} domain.FileListResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
 // @Failure 500 {object} domain.ErrorResponse
 // @Security ApiKeyAuth
 // @Router /api/status [get]
 func (h *Handler) HandleStatus(c *gin.Context) {
-	files, err := h.videoUseCase.ListProcessedFiles()
+	files, err := h.videoUseCase.ListProcessedFiles(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Success: false, Message: "Erro ao listar arquivos"})
 		return
@@ -162,7 +416,7 @@ func (h *Handler) HandleStatus(c *gin.Context) {
 
 // HandleRegister registers a new user
 // @Summary Register a new user
-// @Description Creates a new user account with email, password, and name.
+// @Description Creates a new user account with email, password, and name, and emails a link to verify the address.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -178,18 +432,30 @@ func (h *Handler) HandleRegister(c *gin.Context) {
 		return
 	}
 
-	response, err := h.userUseCase.Register(req.Email, req.Password, req.Name)
+	response, err := h.userUseCase.Register(c.Request.Context(), req.Email, req.Password, req.Name)
+	event := &domain.AuditEvent{Action: domain.AuditActionRegister, Outcome: auditOutcome(err), Target: "email:" + req.Email}
+	if err == nil {
+		event.ActorID = &response.User.ID
+	}
+	h.audit(c, event)
 	if err != nil {
 		c.JSON(http.StatusConflict, gin.H{"success": false, "message": err.Error()})
 		return
 	}
 
+	// The account is usable right away; a failed email can be resent from /api/me/verify-email
+	if err := h.accountUseCase.SendVerificationEmail(c.Request.Context(), response.User.ID); err != nil {
+		fmt.Printf("⚠️ Erro ao enviar e-mail de verificação para o usuário %d: %v\n", response.User.ID, err)
+	}
+
 	c.JSON(http.StatusCreated, response)
 }
 
 // HandleLogin authenticates a user
 // @Summary Authenticate user
 // @Description Logs in a user and returns a JWT token.
+// @Description Accounts with two-factor authentication get mfa_required and an mfa_token instead, to be exchanged at /auth/mfa/verify.
+// @Description Repeated failures for the same account or client IP delay further attempts and eventually lock them out for 15 minutes (429 with Retry-After).
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -197,6 +463,7 @@ func (h *Handler) HandleRegister(c *gin.Context) {
 // @Success 200 {object} domain.AuthResponse
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 401 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse
 // @Router /login [post]
 func (h *Handler) HandleLogin(c *gin.Context) {
 	var req domain.LoginRequest
@@ -205,7 +472,14 @@ func (h *Handler) HandleLogin(c *gin.Context) {
 		return
 	}
 
-	response, err := h.userUseCase.Login(req.Email, req.Password)
+	response, err := h.userUseCase.Login(c.Request.Context(), req.Email, req.Password, c.ClientIP())
+	var locked *domain.LoginLockedError
+	h.auditLogin(c, domain.AuditActionLogin, "email:"+req.Email, response, err)
+	if errors.As(err, &locked) {
+		c.Header("Retry-After", strconv.Itoa(locked.Seconds()))
+		c.JSON(http.StatusTooManyRequests, gin.H{"success": false, "message": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": err.Error()})
 		return
@@ -213,3 +487,78 @@ func (h *Handler) HandleLogin(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// HandleRefresh rotates a refresh token
+// @Summary Refresh access token
+// @Description Exchanges a refresh token for a new access token and a new refresh token. Each refresh token can only be used once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.RefreshRequest true "Refresh token"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /auth/refresh [post]
+func (h *Handler) HandleRefresh(c *gin.Context) {
+	var req domain.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	response, err := h.userUseCase.Refresh(c.Request.Context(), req.RefreshToken)
+	if errors.Is(err, domain.ErrSessionRevoked) {
+		// A reused refresh token revokes the whole session and may mean it was stolen
+		h.audit(c, &domain.AuditEvent{Action: domain.AuditActionRefresh, Outcome: domain.AuditOutcomeDenied, Details: map[string]string{"reason": err.Error()}})
+	}
+	if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrSessionRevoked) {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao renovar sessão: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// HandleLogout ends a session
+// @Summary Logout
+// @Description Revokes the session of the given refresh token. Access tokens of that session stop being accepted.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.RefreshRequest true "Refresh token"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/logout [post]
+func (h *Handler) HandleLogout(c *gin.Context) {
+	var req domain.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	err := h.userUseCase.Logout(c.Request.Context(), req.RefreshToken)
+	h.audit(c, &domain.AuditEvent{Action: domain.AuditActionLogout, Outcome: auditOutcome(err)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao encerrar sessão: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleJWKS publishes the public keys used to verify access tokens
+// @Summary JSON Web Key Set
+// @Description Public keys (RFC 7517) that verify the access tokens issued by this API, identified by "kid". Empty when HS256 is in use.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *Handler) HandleJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.tokenSigner.PublicKeys())
+}
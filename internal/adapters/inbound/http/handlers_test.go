@@ -0,0 +1,274 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	outbound_memory "video-processor/internal/adapters/outbound/memory"
+	outbound_signing "video-processor/internal/adapters/outbound/signing"
+	outbound_storage "video-processor/internal/adapters/outbound/storage"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+	core_services "video-processor/internal/core/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is the router of newTestServer along with what tests inspect behind it
+type testServer struct {
+	*gin.Engine
+	publisher *outbound_memory.MemoryEventPublisher
+	mailer    *recordingMailer
+	dir       string // Root of the uploads, outputs and temp storage directories
+}
+
+// recordingMailer keeps the emails sent, so tests can follow their links
+type recordingMailer struct {
+	mu     sync.Mutex
+	emails []domain.Email
+}
+
+func (m *recordingMailer) Send(email domain.Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emails = append(m.emails, email)
+	return nil
+}
+
+// last returns the most recent email sent to address
+func (m *recordingMailer) last(t *testing.T, address string) domain.Email {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.emails) - 1; i >= 0; i-- {
+		if m.emails[i].To == address {
+			return m.emails[i]
+		}
+	}
+	t.Fatalf("no email sent to %s", address)
+	return domain.Email{}
+}
+
+// newTestServer wires the real services to the in-memory adapters, the way --dev does
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	store := outbound_memory.NewStore()
+	users := outbound_memory.NewMemoryUserRepository(store)
+	videos := outbound_memory.NewMemoryVideoRepository(store)
+	dataExports := outbound_memory.NewMemoryDataExportRepository(store)
+	refreshTokens := outbound_memory.NewMemoryRefreshTokenRepository(store)
+	audit := outbound_memory.NewMemoryAuditRepository(store)
+	mfa := outbound_memory.NewMemoryMFARepository(store)
+	organizations := outbound_memory.NewMemoryOrganizationRepository(store)
+	unitOfWork := outbound_memory.NewMemoryUnitOfWork(store)
+	publisher := outbound_memory.NewMemoryEventPublisher()
+	storage := outbound_storage.NewFSStorage(dir+"/uploads", dir+"/outputs", dir+"/temp", time.Minute)
+	signer := outbound_signing.NewHMACSigner(outbound_signing.DefaultDevSecret)
+	mailer := &recordingMailer{}
+
+	userService := core_services.NewUserService(users, refreshTokens, outbound_memory.NewMemoryUserIdentityRepository(store), mfa,
+		outbound_memory.NewMemoryLoginThrottleRepository(store), audit, signer)
+	accountService := core_services.NewAccountService(users, outbound_memory.NewMemoryAccountTokenRepository(store), refreshTokens, mailer, "http://localhost")
+	handler := NewHandler(
+		core_services.NewVideoService(storage, videos, organizations, publisher),
+		userService,
+		core_services.NewAPIKeyService(outbound_memory.NewMemoryAPIKeyRepository(store), users),
+		nil,
+		accountService,
+		core_services.NewMFAService(mfa, users, audit),
+		core_services.NewProfileService(users, videos, dataExports, storage, refreshTokens, accountService, audit, unitOfWork),
+		core_services.NewDataExportService(dataExports, users, videos, storage, mailer, "http://localhost"),
+		core_services.NewOrganizationService(organizations, users, unitOfWork),
+		core_services.NewAuditService(audit, 0),
+		core_services.NewHealthService(map[string]ports.HealthChecker{"storage": storage}),
+		signer,
+		storage,
+	)
+
+	r := gin.New()
+	r.Use(RequestID())
+	handler.RegisterRoutes(r)
+	return &testServer{Engine: r, publisher: publisher, mailer: mailer, dir: dir}
+}
+
+func doJSON(r *gin.Engine, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_RegisterAndUpload(t *testing.T) {
+	srv := newTestServer(t)
+	r := srv.Engine
+	credentials := map[string]string{"email": "user@example.com", "password": "password123", "name": "User"}
+
+	t.Run("duplicate email is rejected", func(t *testing.T) {
+		w := doJSON(r, http.MethodPost, "/register", "", credentials)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w = doJSON(r, http.MethodPost, "/register", "", credentials)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("uploaded video is listed and published", func(t *testing.T) {
+		w := doJSON(r, http.MethodPost, "/login", "", credentials)
+		require.Equal(t, http.StatusOK, w.Code)
+		var auth domain.AuthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &auth))
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("video", "clip.mp4")
+		part.Write([]byte("not really a video"))
+		form.Close()
+		req := httptest.NewRequest(http.MethodPost, "/api/upload", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+auth.Token)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = doJSON(r, http.MethodGet, "/api/videos", auth.Token, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var list struct {
+			Videos []domain.Video `json:"videos"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Videos, 1)
+		assert.Equal(t, "clip.mp4", list.Videos[0].OriginalFilename)
+		assert.Equal(t, domain.StatusPending, list.Videos[0].Status)
+
+		events := srv.publisher.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, list.Videos[0].ID, events[0].VideoID)
+	})
+}
+
+// registerAndLogin creates an account and returns its session token
+func registerAndLogin(t *testing.T, r *gin.Engine, credentials map[string]string) string {
+	t.Helper()
+	w := doJSON(r, http.MethodPost, "/register", "", credentials)
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = doJSON(r, http.MethodPost, "/login", "", credentials)
+	require.Equal(t, http.StatusOK, w.Code)
+	var auth domain.AuthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &auth))
+	return auth.Token
+}
+
+func TestHandler_DeleteAccountRemovesExports(t *testing.T) {
+	srv := newTestServer(t)
+	credentials := map[string]string{"email": "export@example.com", "password": "password123", "name": "Export"}
+	token := registerAndLogin(t, srv.Engine, credentials)
+
+	w := doJSON(srv.Engine, http.MethodPost, "/api/me/export", token, nil)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	var export domain.DataExport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+	require.Eventually(t, func() bool {
+		w := doJSON(srv.Engine, http.MethodGet, fmt.Sprintf("/api/me/exports/%d", export.ID), token, nil)
+		return w.Code == http.StatusOK && json.Unmarshal(w.Body.Bytes(), &export) == nil && export.Status != domain.ExportStatusPending
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, domain.ExportStatusReady, export.Status)
+	archives, err := filepath.Glob(filepath.Join(srv.dir, "outputs", "exports", "*"))
+	require.NoError(t, err)
+	require.Len(t, archives, 1)
+
+	w = doJSON(srv.Engine, http.MethodDelete, "/api/me", token, domain.DeleteAccountRequest{Password: credentials["password"]})
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	archives, err = filepath.Glob(filepath.Join(srv.dir, "outputs", "exports", "*"))
+	require.NoError(t, err)
+	assert.Empty(t, archives)
+}
+
+func TestHandler_ProcessedFilesRequireAuthorization(t *testing.T) {
+	srv := newTestServer(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(srv.dir, "outputs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(srv.dir, "outputs", "frames.zip"), []byte("zip"), 0644))
+	token := registerAndLogin(t, srv.Engine, map[string]string{"email": "user@example.com", "password": "password123", "name": "User"})
+
+	assert.Equal(t, http.StatusNotFound, doJSON(srv.Engine, http.MethodGet, "/download/frames.zip", "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, doJSON(srv.Engine, http.MethodGet, "/api/admin/files/frames.zip", "", nil).Code)
+	assert.Equal(t, http.StatusForbidden, doJSON(srv.Engine, http.MethodGet, "/api/admin/files/frames.zip", token, nil).Code)
+}
+
+func TestHandler_PasswordResetLink(t *testing.T) {
+	srv := newTestServer(t)
+	credentials := map[string]string{"email": "reset@example.com", "password": "password123", "name": "Reset"}
+	registerAndLogin(t, srv.Engine, credentials)
+
+	w := doJSON(srv.Engine, http.MethodPost, "/auth/forgot-password", "", map[string]string{"email": credentials["email"]})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	link := regexp.MustCompile(`http://localhost(/\S+)`).FindStringSubmatch(srv.mailer.last(t, credentials["email"]).Body)
+	require.NotNil(t, link, "reset email without a link")
+
+	// The emailed link opens the form, which posts the token with the new password
+	w = doJSON(srv.Engine, http.MethodGet, link[1], "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+	assert.Contains(t, w.Body.String(), "fetch('/auth/reset-password'")
+
+	token := strings.TrimPrefix(link[1], "/auth/reset-password?token=")
+	require.NotEqual(t, link[1], token, "link does not point at the reset page")
+	w = doJSON(srv.Engine, http.MethodPost, "/auth/reset-password", "", domain.ResetPasswordRequest{Token: token, Password: "new-password"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	credentials["password"] = "new-password"
+	assert.Equal(t, http.StatusOK, doJSON(srv.Engine, http.MethodPost, "/login", "", credentials).Code)
+}
+
+func TestRequestID(t *testing.T) {
+	r := gin.New()
+	r.Use(RequestID())
+	var metadata domain.RequestMetadata
+	r.GET("/", func(c *gin.Context) {
+		metadata = domain.RequestMetadataFromContext(c.Request.Context())
+	})
+
+	t.Run("client ID is kept and passed to services", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "203.0.113.7:1234"
+		req.Header.Set("User-Agent", "test-agent")
+		req.Header.Set(requestIDHeader, "req-1")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, "req-1", w.Header().Get(requestIDHeader))
+		assert.Equal(t, domain.RequestMetadata{IP: "203.0.113.7", UserAgent: "test-agent", RequestID: "req-1"}, metadata)
+	})
+
+	t.Run("unsafe ID is replaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(requestIDHeader, "bad id\n")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Len(t, w.Header().Get(requestIDHeader), 32)
+		assert.Equal(t, w.Header().Get(requestIDHeader), metadata.RequestID)
+	})
+}
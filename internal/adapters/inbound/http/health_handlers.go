@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleLiveness reports that the process is up
+// @Summary Liveness probe
+// @Description Answers as long as the process can serve HTTP. Dependencies are not checked, so a failing database does not restart the container.
+// @Tags health
+// @Produce json
+// @Success 200 {object} domain.LivenessResponse
+// @Router /healthz [get]
+func (h *Handler) HandleLiveness(c *gin.Context) {
+	c.JSON(http.StatusOK, domain.LivenessResponse{Status: "ok"})
+}
+
+// HandleReadiness reports whether the instance can take traffic
+// @Summary Readiness probe
+// @Description Probes Postgres, NATS with its JetStream stream and storage writability, with the latency of each. Returns 503 when any is down or the server is shutting down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} domain.ReadinessReport
+// @Failure 503 {object} domain.ReadinessReport
+// @Router /readyz [get]
+func (h *Handler) HandleReadiness(c *gin.Context) {
+	report := h.healthUseCase.Readiness(c.Request.Context())
+
+	c.Header("Cache-Control", "no-store")
+	if !report.Ready() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleMFAEnroll starts TOTP enrollment
+// @Summary Start two-factor enrollment
+// @Description Generates a TOTP secret and its otpauth:// URI (show it as a QR code). MFA is only enabled after /api/me/mfa/confirm.
+// @Tags mfa
+// @Produce json
+// @Success 200 {object} domain.MFAEnrollmentResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me/mfa/enroll [post]
+func (h *Handler) HandleMFAEnroll(c *gin.Context) {
+	response, err := h.mfaUseCase.BeginEnrollment(c.Request.Context(), c.GetInt64("userID"))
+	if errors.Is(err, domain.ErrMFAAlreadyEnabled) {
+		c.JSON(http.StatusConflict, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao iniciar verificação em duas etapas: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// HandleMFAConfirm enables TOTP after checking a first code
+// @Summary Confirm two-factor enrollment
+// @Description Enables MFA with a code from the authenticator app and returns single-use recovery codes. They are only shown once.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body domain.MFACodeRequest true "TOTP code"
+// @Success 200 {object} domain.MFARecoveryCodesResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me/mfa/confirm [post]
+func (h *Handler) HandleMFAConfirm(c *gin.Context) {
+	var req domain.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	codes, err := h.mfaUseCase.ConfirmEnrollment(c.Request.Context(), c.GetInt64("userID"), req.Code)
+	switch {
+	case errors.Is(err, domain.ErrInvalidMFACode), errors.Is(err, domain.ErrMFANotEnrolled):
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"success": false, "message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao ativar verificação em duas etapas: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.MFARecoveryCodesResponse{Success: true, RecoveryCodes: codes})
+}
+
+// HandleMFADisable turns TOTP off
+// @Summary Disable two-factor authentication
+// @Description Disables MFA and deletes the recovery codes. Requires a current TOTP code or an unused recovery code.
+// @Tags mfa
+// @Accept json
+// @Param request body domain.MFACodeRequest true "TOTP or recovery code"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me/mfa [delete]
+func (h *Handler) HandleMFADisable(c *gin.Context) {
+	var req domain.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	err := h.mfaUseCase.Disable(c.Request.Context(), c.GetInt64("userID"), req.Code)
+	if errors.Is(err, domain.ErrInvalidMFACode) || errors.Is(err, domain.ErrMFANotEnabled) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao desativar verificação em duas etapas: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleVerifyMFA finishes a two-step login
+// @Summary Verify MFA code
+// @Description Exchanges the mfa_token returned by /login, valid for 5 minutes, and a TOTP or recovery code for the access and refresh tokens.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.VerifyMFARequest true "Challenge token and code"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse
+// @Router /auth/mfa/verify [post]
+func (h *Handler) HandleVerifyMFA(c *gin.Context) {
+	var req domain.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	response, err := h.userUseCase.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code, c.ClientIP())
+	var locked *domain.LoginLockedError
+	h.auditLogin(c, domain.AuditActionMFAVerify, "", response, err)
+	switch {
+	case errors.As(err, &locked):
+		c.Header("Retry-After", strconv.Itoa(locked.Seconds()))
+		c.JSON(http.StatusTooManyRequests, gin.H{"success": false, "message": err.Error()})
+		return
+	case errors.Is(err, domain.ErrInvalidToken), errors.Is(err, domain.ErrInvalidMFACode):
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao verificar código: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
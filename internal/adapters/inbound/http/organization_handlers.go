@@ -0,0 +1,240 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleCreateOrganization creates an organization owned by the authenticated user
+// @Summary Create an organization
+// @Description Creates an organization for sharing videos with a team. The creator becomes its owner.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateOrganizationRequest true "Organization data"
+// @Success 201 {object} domain.Organization
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/organizations [post]
+func (h *Handler) HandleCreateOrganization(c *gin.Context) {
+	var req domain.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	org, err := h.organizationUseCase.CreateOrganization(c.Request.Context(), c.GetInt64("userID"), req.Name)
+	if errors.Is(err, domain.ErrInvalidOrgName) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao criar organização: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+// HandleListOrganizations lists the organizations of the authenticated user
+// @Summary List my organizations
+// @Description Lists the organizations the user belongs to, with the user's role in each.
+// @Tags organizations
+// @Produce json
+// @Success 200 {object} domain.ListOrganizationsResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/organizations [get]
+func (h *Handler) HandleListOrganizations(c *gin.Context) {
+	orgs, err := h.organizationUseCase.ListOrganizations(c.Request.Context(), c.GetInt64("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao listar organizações: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"organizations": orgs,
+	})
+}
+
+// HandleListMembers lists the members of an organization
+// @Summary List organization members
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} domain.ListMembersResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/organizations/{id}/members [get]
+func (h *Handler) HandleListMembers(c *gin.Context) {
+	orgID, ok := organizationIDParam(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationUseCase.ListMembers(c.Request.Context(), c.GetInt64("userID"), orgID)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"members": members,
+	})
+}
+
+// HandleAddMember adds a registered user to an organization
+// @Summary Add an organization member
+// @Description Only owners can add members. Roles: owner (manages members), editor (uploads) and viewer (lists and downloads).
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body domain.AddMemberRequest true "Member data"
+// @Success 201 {object} domain.OrganizationMember
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/organizations/{id}/members [post]
+func (h *Handler) HandleAddMember(c *gin.Context) {
+	orgID, ok := organizationIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	member, err := h.organizationUseCase.AddMember(c.Request.Context(), c.GetInt64("userID"), orgID, req.Email, req.Role)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// HandleUpdateMemberRole changes the role of an organization member
+// @Summary Change a member's role
+// @Description Only owners can change roles. The last owner cannot be demoted.
+// @Tags organizations
+// @Accept json
+// @Param id path int true "Organization ID"
+// @Param userId path int true "User ID"
+// @Param request body domain.UpdateMemberRoleRequest true "New role"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/organizations/{id}/members/{userId} [patch]
+func (h *Handler) HandleUpdateMemberRole(c *gin.Context) {
+	orgID, ok := organizationIDParam(c)
+	if !ok {
+		return
+	}
+	memberID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de usuário inválido"})
+		return
+	}
+
+	var req domain.UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	if err := h.organizationUseCase.UpdateMemberRole(c.Request.Context(), c.GetInt64("userID"), orgID, memberID, req.Role); err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleRemoveMember removes a member from an organization
+// @Summary Remove an organization member
+// @Description Owners can remove any member; other members can only remove themselves. The last owner cannot leave.
+// @Tags organizations
+// @Param id path int true "Organization ID"
+// @Param userId path int true "User ID"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/organizations/{id}/members/{userId} [delete]
+func (h *Handler) HandleRemoveMember(c *gin.Context) {
+	orgID, ok := organizationIDParam(c)
+	if !ok {
+		return
+	}
+	memberID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de usuário inválido"})
+		return
+	}
+
+	err = h.organizationUseCase.RemoveMember(c.Request.Context(), c.GetInt64("userID"), orgID, memberID)
+	h.audit(c, &domain.AuditEvent{
+		Action:  domain.AuditActionMemberRemoved,
+		Outcome: auditOutcome(err),
+		Target:  fmt.Sprintf("user:%d", memberID),
+		Details: map[string]string{"organization_id": strconv.FormatInt(orgID, 10)},
+	})
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func organizationIDParam(c *gin.Context) (int64, bool) {
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de organização inválido"})
+		return 0, false
+	}
+	return orgID, true
+}
+
+func respondOrganizationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidOrgRole):
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+	case errors.Is(err, domain.ErrOrgPermissionDenied):
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": err.Error()})
+	case errors.Is(err, domain.ErrOrganizationNotFound), errors.Is(err, domain.ErrMemberNotFound), errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+	case errors.Is(err, domain.ErrAlreadyMember), errors.Is(err, domain.ErrLastOwner):
+		c.JSON(http.StatusConflict, gin.H{"success": false, "message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro na organização: " + err.Error()})
+	}
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetProfile returns the authenticated user
+// @Summary Get my profile
+// @Tags profile
+// @Produce json
+// @Success 200 {object} domain.User
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me [get]
+func (h *Handler) HandleGetProfile(c *gin.Context) {
+	user, err := h.profileUseCase.GetProfile(c.Request.Context(), c.GetInt64("userID"))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao buscar perfil: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// HandleUpdateProfile changes the authenticated user's name or email
+// @Summary Update my profile
+// @Description Updates name and/or email. A new email is marked as unverified and a verification link is sent to it.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param request body domain.UpdateProfileRequest true "Fields to change"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me [patch]
+func (h *Handler) HandleUpdateProfile(c *gin.Context) {
+	var req domain.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	user, err := h.profileUseCase.UpdateProfile(c.Request.Context(), c.GetInt64("userID"), req)
+	switch {
+	case errors.Is(err, domain.ErrInvalidName):
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	case errors.Is(err, domain.ErrEmailTaken):
+		c.JSON(http.StatusConflict, gin.H{"success": false, "message": err.Error()})
+		return
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao atualizar perfil: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// HandleChangePassword changes the authenticated user's password
+// @Summary Change my password
+// @Description Requires the current password. Every other session of the account is revoked; the current one stays valid.
+// @Tags profile
+// @Accept json
+// @Param request body domain.ChangePasswordRequest true "Current and new password"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me/password [post]
+func (h *Handler) HandleChangePassword(c *gin.Context) {
+	var req domain.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	err := h.profileUseCase.ChangePassword(c.Request.Context(), c.GetInt64("userID"), c.GetString("sessionID"), req.CurrentPassword, req.NewPassword)
+	h.audit(c, &domain.AuditEvent{Action: domain.AuditActionPasswordChanged, Outcome: auditOutcome(err)})
+	if errors.Is(err, domain.ErrInvalidPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao alterar senha: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleDeleteAccount deletes the authenticated user and all their data
+// @Summary Delete my account
+// @Description Permanently deletes the account, its videos and extracted frames. Requires the password; accounts created through SSO must have logged in within the last 5 minutes instead.
+// @Tags profile
+// @Accept json
+// @Param request body domain.DeleteAccountRequest false "Password confirmation"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Security ApiKeyAuth
+// @Router /api/me [delete]
+func (h *Handler) HandleDeleteAccount(c *gin.Context) {
+	var req domain.DeleteAccountRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Dados inválidos: " + err.Error()})
+			return
+		}
+	}
+
+	principal := c.MustGet("principal").(*domain.Principal)
+	err := h.profileUseCase.DeleteAccount(c.Request.Context(), principal.UserID, principal.AuthenticatedAt, req.Password)
+	if errors.Is(err, domain.ErrInvalidPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrReauthenticationRequired) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao excluir conta: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
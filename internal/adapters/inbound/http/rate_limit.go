@@ -0,0 +1,57 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// rateLimitRejections lives in the default registry, which main serves on /metrics
+var rateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_rate_limit_rejections_total",
+	Help: "Requests rejected by the rate limiter, by policy and key type.",
+}, []string{"policy", "key_type"})
+
+// RateLimit takes one token per request from a bucket keyed by the authenticated user, or by
+// the client IP for anonymous requests. It must run after AuthMiddleware to key by user.
+// Limiter errors let the request through so an unavailable backend does not take the API down.
+func RateLimit(limiter ports.RateLimiter, policy domain.RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keyType, key := "ip", c.ClientIP()
+		if userID, ok := c.Get("userID"); ok {
+			keyType, key = "user", strconv.FormatInt(userID.(int64), 10)
+		}
+
+		decision, err := limiter.Allow(c.Request.Context(), keyType+":"+key, policy)
+		if err != nil {
+			fmt.Printf("⚠️ Erro no rate limiter (%s): %v\n", policy.Name, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", decision.Limit, ceilSeconds(policy.Period)))
+
+		if !decision.Allowed {
+			rateLimitRejections.WithLabelValues(policy.Name, keyType).Inc()
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"success": false, "message": "Muitas requisições. Tente novamente mais tarde."})
+			return
+		}
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRateLimiter answers every Allow with decision and err, recording the keys asked for
+type stubRateLimiter struct {
+	decision domain.RateLimitDecision
+	err      error
+	keys     []string
+}
+
+func (l *stubRateLimiter) Allow(ctx context.Context, key string, policy domain.RateLimitPolicy) (domain.RateLimitDecision, error) {
+	l.keys = append(l.keys, key)
+	return l.decision, l.err
+}
+
+var testRateLimitPolicy = domain.RateLimitPolicy{Name: "test", Burst: 5, Period: time.Minute}
+
+// newRateLimitedRouter serves GET /limited behind RateLimit, authenticating as userID when non-zero
+func newRateLimitedRouter(limiter *stubRateLimiter, userID int64) *gin.Engine {
+	r := gin.New()
+	r.GET("/limited", func(c *gin.Context) {
+		if userID != 0 {
+			c.Set("userID", userID)
+		}
+	}, RateLimit(limiter, testRateLimitPolicy), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("allowed request carries the limit headers", func(t *testing.T) {
+		limiter := &stubRateLimiter{decision: domain.RateLimitDecision{Allowed: true, Limit: 5, Remaining: 4, ResetAfter: 12500 * time.Millisecond}}
+		w := httptest.NewRecorder()
+
+		newRateLimitedRouter(limiter, 0).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/limited", nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "4", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "13", w.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "5;w=60", w.Header().Get("RateLimit-Policy"))
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("rejected request gets 429 with Retry-After and is counted", func(t *testing.T) {
+		limiter := &stubRateLimiter{decision: domain.RateLimitDecision{Limit: 5, RetryAfter: 1500 * time.Millisecond, ResetAfter: time.Minute}}
+		w := httptest.NewRecorder()
+
+		newRateLimitedRouter(limiter, 0).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/limited", nil))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"success": false, "message": "Muitas requisições. Tente novamente mais tarde."}`, w.Body.String())
+
+		// The counter is in the default registry main serves on /metrics
+		metrics := httptest.NewRecorder()
+		promhttp.Handler().ServeHTTP(metrics, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Contains(t, metrics.Body.String(), `http_rate_limit_rejections_total{key_type="ip",policy="test"} 1`)
+	})
+
+	t.Run("authenticated requests are keyed by user, anonymous ones by IP", func(t *testing.T) {
+		limiter := &stubRateLimiter{decision: domain.RateLimitDecision{Allowed: true}}
+		req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+		req.RemoteAddr = "203.0.113.7:1234"
+
+		newRateLimitedRouter(limiter, 42).ServeHTTP(httptest.NewRecorder(), req)
+		newRateLimitedRouter(limiter, 0).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, []string{"user:42", "ip:203.0.113.7"}, limiter.keys)
+	})
+
+	t.Run("limiter errors let the request through", func(t *testing.T) {
+		limiter := &stubRateLimiter{err: errors.New("backend unavailable")}
+		w := httptest.NewRecorder()
+
+		newRateLimitedRouter(limiter, 0).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/limited", nil))
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+}
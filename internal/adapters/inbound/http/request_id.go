@@ -0,0 +1,48 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	requestIDHeader    = "X-Request-ID"
+	maxRequestIDLength = 64
+)
+
+// RequestID tags each request with an ID, echoed in X-Request-ID and recorded in audit events.
+// An ID sent by the client or a proxy is kept when it is short and made of safe characters.
+// The ID, client IP and user agent also go into the request context for audits recorded by services.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !isValidRequestID(id) {
+			random := make([]byte, 16)
+			rand.Read(random)
+			id = hex.EncodeToString(random)
+		}
+		c.Set("requestID", id)
+		c.Header(requestIDHeader, id)
+		c.Request = c.Request.WithContext(domain.ContextWithRequestMetadata(c.Request.Context(), domain.RequestMetadata{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: id,
+		}))
+		c.Next()
+	}
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"video-processor/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookie binds the login to the browser that started it, preventing login CSRF
+const oidcStateCookie = "oidc_state"
+
+// HandleOIDCLogin starts a single sign-on login
+// @Summary Start single sign-on
+// @Description Redirects the browser to the corporate identity provider (OpenID Connect authorization code flow with PKCE).
+// @Tags auth
+// @Success 302
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/oidc/login [get]
+func (h *Handler) HandleOIDCLogin(c *gin.Context) {
+	authURL, state, err := h.ssoUseCase.BeginLogin(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Erro ao iniciar login SSO: " + err.Error()})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, 600, "/auth/oidc", "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// HandleOIDCCallback finishes a single sign-on login
+// @Summary Finish single sign-on
+// @Description Receives the identity provider redirect, validates state, nonce and ID token, links or provisions the user by verified email and returns the API's own tokens.
+// @Tags auth
+// @Produce json
+// @Param state query string true "State returned by the identity provider"
+// @Param code query string true "Authorization code"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Router /auth/oidc/callback [get]
+func (h *Handler) HandleOIDCCallback(c *gin.Context) {
+	if idpError := c.Query("error"); idpError != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Login recusado pelo provedor: " + idpError + " " + c.Query("error_description")})
+		return
+	}
+
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Parâmetros state e code são obrigatórios"})
+		return
+	}
+
+	cookieState, err := c.Cookie(oidcStateCookie)
+	c.SetCookie(oidcStateCookie, "", -1, "/auth/oidc", "", c.Request.TLS != nil, true)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": domain.ErrInvalidLoginState.Error()})
+		return
+	}
+
+	response, err := h.ssoUseCase.CompleteLogin(c.Request.Context(), state, code)
+	h.auditLogin(c, domain.AuditActionSSOLogin, "", response, err)
+	switch {
+	case errors.Is(err, domain.ErrInvalidLoginState):
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	case errors.Is(err, domain.ErrExternalEmailUnverified):
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": "Falha no login SSO: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
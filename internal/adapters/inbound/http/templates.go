@@ -139,7 +139,7 @@ func getHTMLForm() string {
                 if (result.success) {
                     showResult(
                         result.message + 
-                        '<br><br><a href="/download/' + result.zip_path + '" class="download-btn">⬇️ Download ZIP</a>',
+                        '<br><br><a href="/api/videos/' + result.video_id + '/download" class="download-btn">⬇️ Download ZIP</a>',
                         'success'
                     );
                     loadFilesList();
@@ -204,3 +204,115 @@ func getHTMLForm() string {
 </body>
 </html>`
 }
+
+// getResetPasswordHTML is the page the reset email links to. The token is read from the
+// URL by the script and posted with the new password to POST /auth/reset-password.
+func getResetPasswordHTML() string {
+	return `
+<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>FIAP X - Redefinir senha</title>
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            max-width: 480px;
+            margin: 50px auto;
+            padding: 20px;
+            background-color: #f5f5f5;
+        }
+        .container {
+            background: white;
+            padding: 30px;
+            border-radius: 10px;
+            box-shadow: 0 2px 10px rgba(0,0,0,0.1);
+        }
+        h1 {
+            color: #333;
+            text-align: center;
+        }
+        input[type="password"] {
+            width: 100%;
+            box-sizing: border-box;
+            margin: 10px 0;
+            padding: 10px;
+        }
+        button {
+            width: 100%;
+            background: #007bff;
+            color: white;
+            padding: 12px;
+            border: none;
+            border-radius: 5px;
+            font-size: 16px;
+            cursor: pointer;
+        }
+        .result {
+            margin-top: 20px;
+            padding: 15px;
+            border-radius: 5px;
+            display: none;
+        }
+        .success { background: #d4edda; color: #155724; }
+        .error { background: #f8d7da; color: #721c24; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1>🔑 Redefinir senha</h1>
+        <form id="resetForm">
+            <input type="password" id="password" placeholder="Nova senha" minlength="6" required>
+            <input type="password" id="confirmation" placeholder="Confirme a nova senha" minlength="6" required>
+            <button type="submit">Redefinir senha</button>
+        </form>
+        <div class="result" id="result"></div>
+    </div>
+
+    <script>
+        const token = new URLSearchParams(window.location.search).get('token');
+        const form = document.getElementById('resetForm');
+
+        function showResult(message, type) {
+            const result = document.getElementById('result');
+            result.textContent = message;
+            result.className = 'result ' + type;
+            result.style.display = 'block';
+        }
+
+        if (!token) {
+            form.style.display = 'none';
+            showResult('Link inválido: token não informado.', 'error');
+        }
+
+        form.addEventListener('submit', async function(e) {
+            e.preventDefault();
+
+            const password = document.getElementById('password').value;
+            if (password !== document.getElementById('confirmation').value) {
+                showResult('As senhas não conferem.', 'error');
+                return;
+            }
+
+            try {
+                const response = await fetch('/auth/reset-password', {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ token: token, password: password })
+                });
+                const result = await response.json();
+                if (result.success) {
+                    form.style.display = 'none';
+                    showResult(result.message, 'success');
+                } else {
+                    showResult('Erro: ' + result.message, 'error');
+                }
+            } catch (error) {
+                showResult('Erro de conexão: ' + error.message, 'error');
+            }
+        });
+    </script>
+</body>
+</html>`
+}
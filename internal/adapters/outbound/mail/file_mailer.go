@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer is meant for development: messages are written as .eml files to dir, or
+// only logged when dir is empty, so links can be followed without an SMTP server.
+func NewFileMailer(dir, from string) (ports.Mailer, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("error creating mail directory: %w", err)
+		}
+	}
+	return &fileMailer{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+func (m *fileMailer) Send(email domain.Email) error {
+	if m.dir == "" {
+		log.Printf("📧 E-mail para %s: %s\n%s", email.To, email.Subject, email.Body)
+		return nil
+	}
+
+	path := filepath.Join(m.dir, time.Now().UTC().Format("20060102_150405.000000000")+".eml")
+	if err := os.WriteFile(path, buildMessage(m.from, email), 0644); err != nil {
+		return fmt.Errorf("error writing email: %w", err)
+	}
+	log.Printf("📧 E-mail para %s salvo em %s", email.To, path)
+	return nil
+}
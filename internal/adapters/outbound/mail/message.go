@@ -0,0 +1,29 @@
+package mail
+
+import (
+	"bytes"
+	"mime"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+)
+
+// headerValue drops line breaks so user-controlled values cannot inject extra headers
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// buildMessage renders an RFC 5322 plain-text UTF-8 message
+func buildMessage(from string, email domain.Email) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + headerValue(from) + "\r\n")
+	buf.WriteString("To: " + headerValue(email.To) + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", headerValue(email.Subject)) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(email.Body, "\n", "\r\n"))
+	return buf.Bytes()
+}
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type smtpMailer struct {
+	addr     string
+	auth     smtp.Auth
+	from     string // From header, e.g. "Fiap X <no-reply@example.com>"
+	envelope string // Bare sender address for the SMTP envelope
+}
+
+// NewSMTPMailer sends through an SMTP relay. STARTTLS is used whenever the server offers it;
+// credentials are optional for relays that trust the network.
+func NewSMTPMailer(host, port, username, password, from string) (ports.Mailer, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", from, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpMailer{
+		addr:     net.JoinHostPort(host, port),
+		auth:     auth,
+		from:     from,
+		envelope: sender.Address,
+	}, nil
+}
+
+func (m *smtpMailer) Send(email domain.Email) error {
+	if err := smtp.SendMail(m.addr, m.auth, m.envelope, []string{headerValue(email.To)}, buildMessage(m.from, email)); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	return nil
+}
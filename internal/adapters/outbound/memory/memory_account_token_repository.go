@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryAccountTokenRepository struct {
+	store *Store
+}
+
+func NewMemoryAccountTokenRepository(store *Store) ports.AccountTokenRepository {
+	return &memoryAccountTokenRepository{
+		store: store,
+	}
+}
+
+func (r *memoryAccountTokenRepository) Create(ctx context.Context, token *domain.AccountToken) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	token.ID = r.store.nextID()
+	token.CreatedAt = now()
+	r.store.accountTokens[token.ID] = *token
+	return nil
+}
+
+func (r *memoryAccountTokenRepository) Consume(ctx context.Context, tokenHash, purpose string) (*domain.AccountToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, t := range r.store.accountTokens {
+		if t.TokenHash == tokenHash && t.Purpose == purpose && t.UsedAt == nil {
+			usedAt := now()
+			t.UsedAt = &usedAt
+			r.store.accountTokens[id] = t
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAccountTokenRepository) InvalidateForUser(ctx context.Context, userID int64, purpose string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	usedAt := now()
+	for id, t := range r.store.accountTokens {
+		if t.UserID == userID && t.Purpose == purpose && t.UsedAt == nil {
+			t.UsedAt = &usedAt
+			r.store.accountTokens[id] = t
+		}
+	}
+	return nil
+}
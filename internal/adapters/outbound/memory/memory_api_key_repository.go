@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryAPIKeyRepository struct {
+	store *Store
+}
+
+func NewMemoryAPIKeyRepository(store *Store) ports.APIKeyRepository {
+	return &memoryAPIKeyRepository{
+		store: store,
+	}
+}
+
+func (r *memoryAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key.ID = r.store.nextID()
+	key.CreatedAt = now()
+	stored := *key
+	stored.Scopes = slices.Clone(key.Scopes)
+	r.store.apiKeys[key.ID] = stored
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, k := range r.store.apiKeys {
+		if k.Prefix == prefix {
+			k.Scopes = slices.Clone(k.Scopes)
+			return &k, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAPIKeyRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var keys []domain.APIKey
+	for _, k := range r.store.apiKeys {
+		if k.UserID == userID {
+			k.Scopes = slices.Clone(k.Scopes)
+			keys = append(keys, k)
+		}
+	}
+	newestFirst(keys, func(k domain.APIKey) (time.Time, int64) { return k.CreatedAt, k.ID })
+	return keys, nil
+}
+
+func (r *memoryAPIKeyRepository) Revoke(ctx context.Context, userID, keyID int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	k, ok := r.store.apiKeys[keyID]
+	if !ok || k.UserID != userID || k.RevokedAt != nil {
+		return false, nil
+	}
+	revokedAt := now()
+	k.RevokedAt = &revokedAt
+	r.store.apiKeys[keyID] = k
+	return true, nil
+}
+
+func (r *memoryAPIKeyRepository) TouchLastUsed(ctx context.Context, keyID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if k, ok := r.store.apiKeys[keyID]; ok {
+		lastUsedAt := now()
+		k.LastUsedAt = &lastUsedAt
+		r.store.apiKeys[keyID] = k
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryAuditRepository struct {
+	store *Store
+}
+
+func NewMemoryAuditRepository(store *Store) ports.AuditRepository {
+	return &memoryAuditRepository{
+		store: store,
+	}
+}
+
+func (r *memoryAuditRepository) Record(ctx context.Context, event *domain.AuditEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if event.Outcome == "" {
+		event.Outcome = domain.AuditOutcomeSuccess
+	}
+	event.ID = r.store.nextID()
+	event.CreatedAt = now()
+	stored := *event
+	stored.Details = maps.Clone(event.Details)
+	if stored.Details == nil {
+		stored.Details = map[string]string{}
+	}
+	r.store.auditEvents = append(r.store.auditEvents, stored)
+	return nil
+}
+
+func (r *memoryAuditRepository) Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var events []domain.AuditEvent
+	for _, e := range r.store.auditEvents {
+		if matchesAuditFilter(e, filter) {
+			e.Details = maps.Clone(e.Details)
+			events = append(events, e)
+		}
+	}
+	newestFirst(events, func(e domain.AuditEvent) (time.Time, int64) { return e.CreatedAt, e.ID })
+
+	if filter.Offset >= len(events) {
+		return nil, nil
+	}
+	events = events[filter.Offset:]
+	if len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
+
+func matchesAuditFilter(e domain.AuditEvent, f domain.AuditFilter) bool {
+	switch {
+	case f.Action != "" && e.Action != f.Action,
+		f.Outcome != "" && e.Outcome != f.Outcome,
+		f.ActorID != nil && (e.ActorID == nil || *e.ActorID != *f.ActorID),
+		f.Target != "" && e.Target != f.Target,
+		f.IP != "" && e.IP != f.IP,
+		f.RequestID != "" && e.RequestID != f.RequestID,
+		f.From != nil && e.CreatedAt.Before(*f.From),
+		f.To != nil && !e.CreatedAt.Before(*f.To):
+		return false
+	}
+	return true
+}
+
+func (r *memoryAuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	kept := r.store.auditEvents[:0]
+	for _, e := range r.store.auditEvents {
+		if !e.CreatedAt.Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	deleted := int64(len(r.store.auditEvents) - len(kept))
+	r.store.auditEvents = kept
+	return deleted, nil
+}
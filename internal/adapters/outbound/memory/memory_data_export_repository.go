@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryDataExportRepository struct {
+	store *Store
+}
+
+func NewMemoryDataExportRepository(store *Store) ports.DataExportRepository {
+	return &memoryDataExportRepository{
+		store: store,
+	}
+}
+
+func (r *memoryDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	export.ID = r.store.nextID()
+	export.CreatedAt = now()
+	r.store.dataExports[export.ID] = domain.DataExport{
+		ID:        export.ID,
+		UserID:    export.UserID,
+		Status:    export.Status,
+		CreatedAt: export.CreatedAt,
+	}
+	return nil
+}
+
+func (r *memoryDataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	e, ok := r.store.dataExports[export.ID]
+	if !ok {
+		return nil
+	}
+	e.Status = export.Status
+	e.FileName = export.FileName
+	e.TokenHash = export.TokenHash
+	e.Error = export.Error
+	e.ExpiresAt = export.ExpiresAt
+	e.CompletedAt = export.CompletedAt
+	r.store.dataExports[export.ID] = e
+	return nil
+}
+
+func (r *memoryDataExportRepository) GetByID(ctx context.Context, id int64) (*domain.DataExport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	e, ok := r.store.dataExports[id]
+	if !ok {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (r *memoryDataExportRepository) GetPendingByUserID(ctx context.Context, userID int64) (*domain.DataExport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var latest *domain.DataExport
+	for _, e := range r.store.dataExports {
+		if e.UserID == userID && e.Status == domain.ExportStatusPending && (latest == nil || e.CreatedAt.After(latest.CreatedAt)) {
+			latest = &e
+		}
+	}
+	return latest, nil
+}
+
+func (r *memoryDataExportRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DataExport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, e := range r.store.dataExports {
+		if tokenHash != "" && e.TokenHash == tokenHash {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryDataExportRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.DataExport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var exports []domain.DataExport
+	for _, e := range r.store.dataExports {
+		if e.UserID == userID {
+			exports = append(exports, e)
+		}
+	}
+	return exports, nil
+}
+
+func (r *memoryDataExportRepository) ListExpired(ctx context.Context, expiredBefore, failedBefore time.Time) ([]domain.DataExport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var exports []domain.DataExport
+	for _, e := range r.store.dataExports {
+		if (e.ExpiresAt != nil && e.ExpiresAt.Before(expiredBefore)) ||
+			(e.Status == domain.ExportStatusFailed && e.CreatedAt.Before(failedBefore)) {
+			exports = append(exports, e)
+		}
+	}
+	return exports, nil
+}
+
+func (r *memoryDataExportRepository) FailPendingBefore(ctx context.Context, createdBefore time.Time, reason string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var failed int64
+	for id, e := range r.store.dataExports {
+		if e.Status == domain.ExportStatusPending && e.CreatedAt.Before(createdBefore) {
+			e.Status = domain.ExportStatusFailed
+			e.Error = reason
+			r.store.dataExports[id] = e
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+func (r *memoryDataExportRepository) Delete(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.dataExports, id)
+	return nil
+}
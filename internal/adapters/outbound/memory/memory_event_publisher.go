@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"video-processor/internal/core/ports"
+)
+
+var errPublisherClosed = errors.New("event publisher is closed")
+
+// UploadEvent is an upload event kept by the in-memory publisher
+type UploadEvent struct {
+	VideoID  int64
+	Filename string
+}
+
+// MemoryEventPublisher keeps published events in memory instead of sending them to a broker.
+// Nothing consumes them, so uploaded videos stay pending unless a test or the caller
+// processes them.
+type MemoryEventPublisher struct {
+	mu     sync.Mutex
+	events []UploadEvent
+	closed bool
+}
+
+var _ ports.EventPublisher = (*MemoryEventPublisher)(nil)
+
+func NewMemoryEventPublisher() *MemoryEventPublisher {
+	return &MemoryEventPublisher{}
+}
+
+func (p *MemoryEventPublisher) PublishUploadEvent(ctx context.Context, videoID int64, filename string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return errPublisherClosed
+	}
+	p.events = append(p.events, UploadEvent{VideoID: videoID, Filename: filename})
+	log.Printf("Event kept in memory: video_id=%d, filename=%s", videoID, filename)
+	return nil
+}
+
+// Events returns the events published so far, oldest first
+func (p *MemoryEventPublisher) Events() []UploadEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]UploadEvent(nil), p.events...)
+}
+
+// Close rejects further publishes, like a drained connection
+func (p *MemoryEventPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	return nil
+}
+
+func (p *MemoryEventPublisher) CheckHealth(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return errPublisherClosed
+	}
+	return nil
+}
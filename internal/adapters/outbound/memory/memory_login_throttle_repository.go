@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryLoginThrottleRepository struct {
+	store *Store
+}
+
+func NewMemoryLoginThrottleRepository(store *Store) ports.LoginThrottleRepository {
+	return &memoryLoginThrottleRepository{
+		store: store,
+	}
+}
+
+func (r *memoryLoginThrottleRepository) Get(ctx context.Context, scope, subject string) (*domain.LoginThrottle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	t, ok := r.store.throttles[throttleKey{scope: scope, subject: subject}]
+	if !ok {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+func (r *memoryLoginThrottleRepository) RegisterFailure(ctx context.Context, scope, subject string, resetBefore time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := throttleKey{scope: scope, subject: subject}
+	t, ok := r.store.throttles[key]
+	if !ok {
+		t = domain.LoginThrottle{Scope: scope, Subject: subject}
+	}
+	if t.LastFailedAt.Before(resetBefore) {
+		t.Failures = 0
+	}
+	t.Failures++
+	t.LastFailedAt = now()
+	r.store.throttles[key] = t
+	return t.Failures, nil
+}
+
+func (r *memoryLoginThrottleRepository) Lock(ctx context.Context, scope, subject string, until time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := throttleKey{scope: scope, subject: subject}
+	if t, ok := r.store.throttles[key]; ok {
+		t.LockedUntil = &until
+		r.store.throttles[key] = t
+	}
+	return nil
+}
+
+func (r *memoryLoginThrottleRepository) Reset(ctx context.Context, scope, subject string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.throttles, throttleKey{scope: scope, subject: subject})
+	return nil
+}
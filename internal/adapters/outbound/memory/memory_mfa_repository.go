@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryMFARepository struct {
+	store *Store
+}
+
+func NewMemoryMFARepository(store *Store) ports.MFARepository {
+	return &memoryMFARepository{
+		store: store,
+	}
+}
+
+func (r *memoryMFARepository) GetByUserID(ctx context.Context, userID int64) (*domain.UserMFA, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	mfa, ok := r.store.mfa[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &mfa, nil
+}
+
+func (r *memoryMFARepository) SavePending(ctx context.Context, mfa *domain.UserMFA) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Never overwrites a confirmed secret
+	if existing, ok := r.store.mfa[mfa.UserID]; ok && existing.ConfirmedAt != nil {
+		return domain.ErrMFAAlreadyEnabled
+	}
+	mfa.CreatedAt = now()
+	r.store.mfa[mfa.UserID] = domain.UserMFA{UserID: mfa.UserID, Secret: mfa.Secret, CreatedAt: mfa.CreatedAt}
+	return nil
+}
+
+func (r *memoryMFARepository) Confirm(ctx context.Context, userID int64, recoveryCodeHashes []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if mfa, ok := r.store.mfa[userID]; ok {
+		confirmedAt := now()
+		mfa.ConfirmedAt = &confirmedAt
+		r.store.mfa[userID] = mfa
+	}
+	codes := make([]recoveryCode, 0, len(recoveryCodeHashes))
+	for _, hash := range recoveryCodeHashes {
+		codes = append(codes, recoveryCode{hash: hash})
+	}
+	r.store.recoveryCodes[userID] = codes
+	return nil
+}
+
+func (r *memoryMFARepository) MarkStepUsed(ctx context.Context, userID, step int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	mfa, ok := r.store.mfa[userID]
+	if !ok || mfa.LastUsedStep >= step {
+		return false, nil
+	}
+	mfa.LastUsedStep = step
+	r.store.mfa[userID] = mfa
+	return true, nil
+}
+
+func (r *memoryMFARepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	codes := r.store.recoveryCodes[userID]
+	for i := range codes {
+		if codes[i].hash == codeHash && !codes[i].used {
+			codes[i].used = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryMFARepository) Delete(ctx context.Context, userID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.mfa, userID)
+	delete(r.store.recoveryCodes, userID)
+	return nil
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryOIDCStateRepository struct {
+	store *Store
+}
+
+func NewMemoryOIDCStateRepository(store *Store) ports.OIDCStateRepository {
+	return &memoryOIDCStateRepository{
+		store: store,
+	}
+}
+
+func (r *memoryOIDCStateRepository) Create(ctx context.Context, state *domain.OIDCLoginState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	state.ID = r.store.nextID()
+	state.CreatedAt = now()
+	r.store.oidcStates[state.ID] = *state
+	return nil
+}
+
+func (r *memoryOIDCStateRepository) Consume(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, s := range r.store.oidcStates {
+		if s.StateHash == stateHash {
+			delete(r.store.oidcStates, id)
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryOIDCStateRepository) DeleteExpired(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	cutoff := time.Now()
+	deleteWhere(r.store.oidcStates, func(_ int64, s domain.OIDCLoginState) bool { return s.ExpiresAt.Before(cutoff) })
+	return nil
+}
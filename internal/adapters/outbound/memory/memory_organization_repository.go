@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryOrganizationRepository struct {
+	store *Store
+}
+
+func NewMemoryOrganizationRepository(store *Store) ports.OrganizationRepository {
+	return &memoryOrganizationRepository{
+		store: store,
+	}
+}
+
+func (r *memoryOrganizationRepository) Create(ctx context.Context, org *domain.Organization, ownerID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	org.ID = r.store.nextID()
+	org.CreatedAt = now()
+	r.store.organizations[org.ID] = domain.Organization{ID: org.ID, Name: org.Name, CreatedAt: org.CreatedAt}
+	r.store.members[memberKey{organizationID: org.ID, userID: ownerID}] = domain.OrganizationMember{
+		OrganizationID: org.ID,
+		UserID:         ownerID,
+		Role:           domain.OrgRoleOwner,
+		CreatedAt:      org.CreatedAt,
+	}
+	return nil
+}
+
+func (r *memoryOrganizationRepository) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	org, ok := r.store.organizations[id]
+	if !ok {
+		return nil, nil
+	}
+	return &org, nil
+}
+
+func (r *memoryOrganizationRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.Organization, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var orgs []domain.Organization
+	for key, m := range r.store.members {
+		if key.userID == userID {
+			org := r.store.organizations[key.organizationID]
+			org.Role = m.Role
+			orgs = append(orgs, org)
+		}
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Name < orgs[j].Name })
+	return orgs, nil
+}
+
+// withUser fills in the member's email and name, which Postgres joins from users
+func (r *memoryOrganizationRepository) withUser(m domain.OrganizationMember) domain.OrganizationMember {
+	u := r.store.users[m.UserID]
+	m.Email = u.Email
+	m.Name = u.Name
+	return m
+}
+
+func (r *memoryOrganizationRepository) GetMember(ctx context.Context, organizationID, userID int64) (*domain.OrganizationMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	m, ok := r.store.members[memberKey{organizationID: organizationID, userID: userID}]
+	if !ok {
+		return nil, nil
+	}
+	m = r.withUser(m)
+	return &m, nil
+}
+
+func (r *memoryOrganizationRepository) ListMembers(ctx context.Context, organizationID int64) ([]domain.OrganizationMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var members []domain.OrganizationMember
+	for key, m := range r.store.members {
+		if key.organizationID == organizationID {
+			members = append(members, r.withUser(m))
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].CreatedAt.Before(members[j].CreatedAt) })
+	return members, nil
+}
+
+func (r *memoryOrganizationRepository) AddMember(ctx context.Context, member *domain.OrganizationMember) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := memberKey{organizationID: member.OrganizationID, userID: member.UserID}
+	if _, ok := r.store.members[key]; ok {
+		return domain.ErrAlreadyMember
+	}
+	member.CreatedAt = now()
+	r.store.members[key] = domain.OrganizationMember{
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+		Role:           member.Role,
+		CreatedAt:      member.CreatedAt,
+	}
+	return nil
+}
+
+func (r *memoryOrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := memberKey{organizationID: organizationID, userID: userID}
+	m, ok := r.store.members[key]
+	if !ok {
+		return domain.ErrMemberNotFound
+	}
+	m.Role = role
+	r.store.members[key] = m
+	return nil
+}
+
+func (r *memoryOrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := memberKey{organizationID: organizationID, userID: userID}
+	if _, ok := r.store.members[key]; !ok {
+		return domain.ErrMemberNotFound
+	}
+	delete(r.store.members, key)
+	return nil
+}
+
+func (r *memoryOrganizationRepository) CountOwners(ctx context.Context, organizationID int64) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for key, m := range r.store.members {
+		if key.organizationID == organizationID && m.Role == domain.OrgRoleOwner {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryRefreshTokenRepository struct {
+	store *Store
+}
+
+func NewMemoryRefreshTokenRepository(store *Store) ports.RefreshTokenRepository {
+	return &memoryRefreshTokenRepository{
+		store: store,
+	}
+}
+
+func (r *memoryRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	token.ID = r.store.nextID()
+	token.CreatedAt = now()
+	r.store.refreshTokens[token.ID] = *token
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, t := range r.store.refreshTokens {
+		if t.TokenHash == tokenHash {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRefreshTokenRepository) MarkRotated(ctx context.Context, id int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.refreshTokens[id]
+	if !ok || t.RotatedAt != nil || t.RevokedAt != nil {
+		return false, nil
+	}
+	rotatedAt := now()
+	t.RotatedAt = &rotatedAt
+	r.store.refreshTokens[id] = t
+	return true, nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.revokeWhere(func(t domain.RefreshToken) bool { return t.FamilyID == familyID })
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, t := range r.store.refreshTokens {
+		if t.FamilyID == familyID && t.RevokedAt != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID int64, keepFamilyID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.revokeWhere(func(t domain.RefreshToken) bool { return t.UserID == userID && t.FamilyID != keepFamilyID })
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) revokeWhere(match func(t domain.RefreshToken) bool) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	revokedAt := now()
+	for id, t := range r.store.refreshTokens {
+		if t.RevokedAt == nil && match(t) {
+			t.RevokedAt = &revokedAt
+			r.store.refreshTokens[id] = t
+		}
+	}
+}
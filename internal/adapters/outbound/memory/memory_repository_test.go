@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"testing"
+	"video-processor/internal/adapters/outbound/repositorytest"
+)
+
+func TestMemoryRepositories(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		store := NewStore()
+		return repositorytest.Repositories{
+			Users:         NewMemoryUserRepository(store),
+			Videos:        NewMemoryVideoRepository(store),
+			Organizations: NewMemoryOrganizationRepository(store),
+			UnitOfWork:    NewMemoryUnitOfWork(store),
+		}
+	})
+}
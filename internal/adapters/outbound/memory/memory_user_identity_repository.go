@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+// errIdentityLinked mirrors the unique constraint on user_identities (issuer, subject)
+var errIdentityLinked = errors.New("external identity already linked")
+
+type memoryUserIdentityRepository struct {
+	store *Store
+}
+
+func NewMemoryUserIdentityRepository(store *Store) ports.UserIdentityRepository {
+	return &memoryUserIdentityRepository{
+		store: store,
+	}
+}
+
+func (r *memoryUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, i := range r.store.identities {
+		if i.Issuer == identity.Issuer && i.Subject == identity.Subject {
+			return errIdentityLinked
+		}
+	}
+	identity.ID = r.store.nextID()
+	identity.CreatedAt = now()
+	r.store.identities[identity.ID] = *identity
+	return nil
+}
+
+func (r *memoryUserIdentityRepository) GetBySubject(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, i := range r.store.identities {
+		if i.Issuer == issuer && i.Subject == subject {
+			return &i, nil
+		}
+	}
+	return nil, nil
+}
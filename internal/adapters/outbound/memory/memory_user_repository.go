@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryUserRepository struct {
+	store *Store
+}
+
+func NewMemoryUserRepository(store *Store) ports.UserRepository {
+	return &memoryUserRepository{
+		store: store,
+	}
+}
+
+// emailTaken reports whether another user already has email; callers hold the lock
+func (r *memoryUserRepository) emailTaken(email string, exceptID int64) bool {
+	for _, u := range r.store.users {
+		if u.Email == email && u.ID != exceptID {
+			return true
+		}
+	}
+	return false
+}
+
+// Create returns domain.ErrEmailTaken for a duplicate email, as the unique constraint would
+func (r *memoryUserRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.emailTaken(user.Email, 0) {
+		return domain.ErrEmailTaken
+	}
+	user.ID = r.store.nextID()
+	user.CreatedAt = now()
+	r.store.users[user.ID] = *user
+
+	id := user.ID
+	recordUndo(ctx, func() { delete(r.store.users, id) })
+	return nil
+}
+
+func (r *memoryUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, u := range r.store.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	u, ok := r.store.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+func (r *memoryUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var users []domain.User
+	for _, u := range r.store.users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *domain.User) error {
+	return r.update(ctx, user.ID, func(u *domain.User) error {
+		if r.emailTaken(user.Email, user.ID) {
+			return domain.ErrEmailTaken
+		}
+		u.Name = user.Name
+		u.Email = user.Email
+		u.EmailVerified = user.EmailVerified
+		return nil
+	})
+}
+
+func (r *memoryUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	return r.update(ctx, id, func(u *domain.User) error {
+		u.Password = passwordHash
+		return nil
+	})
+}
+
+func (r *memoryUserRepository) MarkEmailVerified(ctx context.Context, id int64) error {
+	return r.update(ctx, id, func(u *domain.User) error {
+		u.EmailVerified = true
+		return nil
+	})
+}
+
+// update applies change to the user with id; like an UPDATE matching no rows, an unknown
+// id is not an error
+func (r *memoryUserRepository) update(ctx context.Context, id int64, change func(u *domain.User) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	previous, ok := r.store.users[id]
+	if !ok {
+		return nil
+	}
+	u := previous
+	if err := change(&u); err != nil {
+		return err
+	}
+	r.store.users[id] = u
+
+	recordUndo(ctx, func() { r.store.users[id] = previous })
+	return nil
+}
+
+// Delete removes the user together with every row that references them, mirroring the
+// ON DELETE CASCADE foreign keys of the schema
+func (r *memoryUserRepository) Delete(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	s := r.store
+	// Personal videos go with the account, while organization videos lose their uploader
+	ownVideos := make(map[int64]bool)
+	orphaned := make(map[int64]domain.Video)
+	for _, v := range s.videos {
+		if v.UserID != id {
+			continue
+		}
+		if v.OrganizationID == nil {
+			ownVideos[v.ID] = true
+		} else {
+			orphaned[v.ID] = v
+			v.UserID = 0
+			s.videos[v.ID] = v
+		}
+	}
+	restores := []func(){
+		func() {
+			for videoID, v := range orphaned {
+				s.videos[videoID] = v
+			}
+		},
+		deleteWhere(s.users, func(_ int64, u domain.User) bool { return u.ID == id }),
+		deleteWhere(s.videos, func(_ int64, v domain.Video) bool { return ownVideos[v.ID] }),
+		deleteWhere(s.statusHistory, func(_ int64, e domain.VideoStatusEvent) bool { return ownVideos[e.VideoID] }),
+		deleteWhere(s.refreshTokens, func(_ int64, t domain.RefreshToken) bool { return t.UserID == id }),
+		deleteWhere(s.apiKeys, func(_ int64, k domain.APIKey) bool { return k.UserID == id }),
+		deleteWhere(s.identities, func(_ int64, i domain.UserIdentity) bool { return i.UserID == id }),
+		deleteWhere(s.accountTokens, func(_ int64, t domain.AccountToken) bool { return t.UserID == id }),
+		deleteWhere(s.mfa, func(userID int64, _ domain.UserMFA) bool { return userID == id }),
+		deleteWhere(s.recoveryCodes, func(userID int64, _ []recoveryCode) bool { return userID == id }),
+		deleteWhere(s.dataExports, func(_ int64, e domain.DataExport) bool { return e.UserID == id }),
+		deleteWhere(s.members, func(k memberKey, _ domain.OrganizationMember) bool { return k.userID == id }),
+	}
+
+	recordUndo(ctx, func() {
+		for _, restore := range restores {
+			restore()
+		}
+	})
+	return nil
+}
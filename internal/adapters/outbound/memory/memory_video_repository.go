@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type memoryVideoRepository struct {
+	store *Store
+}
+
+func NewMemoryVideoRepository(store *Store) ports.VideoRepository {
+	return &memoryVideoRepository{
+		store: store,
+	}
+}
+
+func (r *memoryVideoRepository) Create(ctx context.Context, video *domain.Video) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, v := range r.store.videos {
+		if v.StorageKey == video.StorageKey {
+			return domain.ErrStorageKeyTaken
+		}
+	}
+	video.ID = r.store.nextID()
+	video.CreatedAt = now()
+	video.UpdatedAt = video.CreatedAt
+	r.store.videos[video.ID] = *video
+	historyID := r.recordStatus(*video)
+
+	id := video.ID
+	recordUndo(ctx, func() {
+		delete(r.store.videos, id)
+		delete(r.store.statusHistory, historyID)
+	})
+	return nil
+}
+
+// Update saves the processing outcome but not the owner or file, recording a status change in the
+// history like the videos_status_history trigger
+func (r *memoryVideoRepository) Update(ctx context.Context, video *domain.Video) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	previous, ok := r.store.videos[video.ID]
+	if !ok {
+		return domain.ErrVideoNotFound
+	}
+	v := previous
+	v.Status = video.Status
+	v.ZipPath = video.ZipPath
+	v.FrameCount = video.FrameCount
+	v.Message = video.Message
+	v.ErrorCode = video.ErrorCode
+	v.WorkerID = video.WorkerID
+	v.OutputSize = video.OutputSize
+	v.ProcessingStartedAt = storedTime(video.ProcessingStartedAt)
+	v.ProcessingFinishedAt = storedTime(video.ProcessingFinishedAt)
+	v.UpdatedAt = now()
+	r.store.videos[v.ID] = v
+	video.UpdatedAt = v.UpdatedAt
+
+	historyID := int64(0)
+	if v.Status != previous.Status {
+		historyID = r.recordStatus(v)
+	}
+
+	recordUndo(ctx, func() {
+		r.store.videos[previous.ID] = previous
+		delete(r.store.statusHistory, historyID)
+	})
+	return nil
+}
+
+// recordStatus appends the video's current status to its history; callers hold the lock
+func (r *memoryVideoRepository) recordStatus(v domain.Video) int64 {
+	id := r.store.nextID()
+	r.store.statusHistory[id] = domain.VideoStatusEvent{
+		VideoID:   v.ID,
+		Status:    v.Status,
+		Message:   v.Message,
+		CreatedAt: v.UpdatedAt,
+	}
+	return id
+}
+
+func (r *memoryVideoRepository) GetByID(ctx context.Context, id int64) (*domain.Video, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	v, ok := r.store.videos[id]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (r *memoryVideoRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	return r.list(ctx, func(v domain.Video) bool { return v.UserID == userID })
+}
+
+func (r *memoryVideoRepository) ListAccessibleByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	return r.list(ctx, func(v domain.Video) bool {
+		if v.OrganizationID == nil {
+			return v.UserID == userID
+		}
+		_, member := r.store.members[memberKey{organizationID: *v.OrganizationID, userID: userID}]
+		return member
+	})
+}
+
+func (r *memoryVideoRepository) List(ctx context.Context) ([]domain.Video, error) {
+	return r.list(ctx, func(domain.Video) bool { return true })
+}
+
+// list returns the matching videos, newest first
+func (r *memoryVideoRepository) list(ctx context.Context, match func(v domain.Video) bool) ([]domain.Video, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var videos []domain.Video
+	for _, v := range r.store.videos {
+		if match(v) {
+			videos = append(videos, v)
+		}
+	}
+	newestFirst(videos, func(v domain.Video) (time.Time, int64) { return v.CreatedAt, v.ID })
+	return videos, nil
+}
+
+func (r *memoryVideoRepository) GetStatusHistoryByUserID(ctx context.Context, userID int64) ([]domain.VideoStatusEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []int64
+	for id, e := range r.store.statusHistory {
+		if v, ok := r.store.videos[e.VideoID]; ok && v.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+	// Ids grow with time, so they order the history the same way as created_at, id
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var events []domain.VideoStatusEvent
+	for _, id := range ids {
+		events = append(events, r.store.statusHistory[id])
+	}
+	return events, nil
+}
+
+// storedTime copies t with the precision the databases keep, so later changes by the caller
+// do not leak into the store
+func storedTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	stored := t.UTC().Truncate(time.Microsecond)
+	return &stored
+}
@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type throttleKey struct {
+	scope   string
+	subject string
+}
+
+type memberKey struct {
+	organizationID int64
+	userID         int64
+}
+
+type recoveryCode struct {
+	hash string
+	used bool
+}
+
+// Store holds every table of the in-memory adapters behind a single lock, so repositories
+// built on the same Store see each other's rows the way tables of one database do: deleting
+// a user cascades to their videos, sessions and memberships, and organization access is
+// resolved from the memberships.
+type Store struct {
+	mu  sync.RWMutex
+	seq int64
+
+	users         map[int64]domain.User
+	videos        map[int64]domain.Video
+	statusHistory map[int64]domain.VideoStatusEvent
+	refreshTokens map[int64]domain.RefreshToken
+	apiKeys       map[int64]domain.APIKey
+	identities    map[int64]domain.UserIdentity
+	accountTokens map[int64]domain.AccountToken
+	throttles     map[throttleKey]domain.LoginThrottle
+	auditEvents   []domain.AuditEvent
+	mfa           map[int64]domain.UserMFA
+	recoveryCodes map[int64][]recoveryCode
+	dataExports   map[int64]domain.DataExport
+	organizations map[int64]domain.Organization
+	members       map[memberKey]domain.OrganizationMember
+	oidcStates    map[int64]domain.OIDCLoginState
+}
+
+// NewStore returns an empty store. Its contents live as long as the process.
+func NewStore() *Store {
+	return &Store{
+		users:         make(map[int64]domain.User),
+		videos:        make(map[int64]domain.Video),
+		statusHistory: make(map[int64]domain.VideoStatusEvent),
+		refreshTokens: make(map[int64]domain.RefreshToken),
+		apiKeys:       make(map[int64]domain.APIKey),
+		identities:    make(map[int64]domain.UserIdentity),
+		accountTokens: make(map[int64]domain.AccountToken),
+		throttles:     make(map[throttleKey]domain.LoginThrottle),
+		mfa:           make(map[int64]domain.UserMFA),
+		recoveryCodes: make(map[int64][]recoveryCode),
+		dataExports:   make(map[int64]domain.DataExport),
+		organizations: make(map[int64]domain.Organization),
+		members:       make(map[memberKey]domain.OrganizationMember),
+		oidcStates:    make(map[int64]domain.OIDCLoginState),
+	}
+}
+
+// nextID hands out ids from one sequence shared by all tables; callers hold the lock
+func (s *Store) nextID() int64 {
+	s.seq++
+	return s.seq
+}
+
+// now is truncated to microseconds, the precision Postgres stores timestamps with
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// newestFirst orders rows by creation time, newest first, breaking ties by id like
+// ORDER BY created_at DESC, id DESC
+func newestFirst[T any](rows []T, key func(T) (time.Time, int64)) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		ti, idi := key(rows[i])
+		tj, idj := key(rows[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return idi > idj
+	})
+}
+
+// deleteWhere removes the matching rows and returns how to put them back
+func deleteWhere[K comparable, V any](rows map[K]V, match func(K, V) bool) (restore func()) {
+	removed := make(map[K]V)
+	for k, v := range rows {
+		if match(k, v) {
+			removed[k] = v
+			delete(rows, k)
+		}
+	}
+	return func() {
+		for k, v := range removed {
+			rows[k] = v
+		}
+	}
+}
+
+type txKey struct{}
+
+// journal collects the undo steps of the changes made inside a unit of work
+type journal struct {
+	undo []func()
+}
+
+// recordUndo registers how to revert a change when ctx runs inside a unit of work.
+// Callers hold the write lock, and undo runs with it held too.
+func recordUndo(ctx context.Context, undo func()) {
+	if j, ok := ctx.Value(txKey{}).(*journal); ok {
+		j.undo = append(j.undo, undo)
+	}
+}
+
+type memoryUnitOfWork struct {
+	store *Store
+}
+
+// NewMemoryUnitOfWork makes the ctx-aware repositories of store atomic: changes made inside
+// Do are reverted when fn fails. Unlike a database transaction it does not isolate them,
+// so concurrent readers see the changes before Do returns.
+func NewMemoryUnitOfWork(store *Store) ports.UnitOfWork {
+	return &memoryUnitOfWork{
+		store: store,
+	}
+}
+
+// Do runs fn with a fresh journal. A nested call hands its journal to the outer one on
+// success, so, like a savepoint, a failure only undoes its own changes.
+func (u *memoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	j := &journal{}
+	if err := fn(context.WithValue(ctx, txKey{}, j)); err != nil {
+		u.store.mu.Lock()
+		for i := len(j.undo) - 1; i >= 0; i-- {
+			j.undo[i]()
+		}
+		u.store.mu.Unlock()
+		return err
+	}
+
+	if outer, ok := ctx.Value(txKey{}).(*journal); ok {
+		outer.undo = append(outer.undo, j.undo...)
+	}
+	return nil
+}
@@ -1,26 +1,41 @@
 package messaging
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 	"video-processor/internal/core/ports"
 
 	"github.com/nats-io/nats.go"
 )
 
 type NatsAdapter struct {
-	nc *nats.Conn
-	js nats.JetStreamContext
+	nc             *nats.Conn
+	js             nats.JetStreamContext
+	closed         chan struct{}
+	publishTimeout time.Duration
 }
 
+// streamName is the JetStream stream the worker consumes upload events from
+const streamName = "video"
+
+// drainTimeout bounds how long Close waits for pending publishes to be flushed.
+const drainTimeout = 10 * time.Second
+
 type uploadEvent struct {
 	VideoID  int64  `json:"video_id"`
 	Filename string `json:"filename"`
 }
 
-func NewNatsAdapter(url string) (ports.EventPublisher, error) {
-	nc, err := nats.Connect(url)
+// NewNatsAdapter connects to url; publishTimeout bounds waiting for the JetStream ack
+func NewNatsAdapter(url string, publishTimeout time.Duration) (ports.EventPublisher, error) {
+	closed := make(chan struct{})
+	nc, err := nats.Connect(url,
+		nats.DrainTimeout(drainTimeout),
+		nats.ClosedHandler(func(*nats.Conn) { close(closed) }),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to NATS: %w", err)
 	}
@@ -32,7 +47,7 @@ func NewNatsAdapter(url string) (ports.EventPublisher, error) {
 
 	// Ensure stream exists
 	_, err = js.AddStream(&nats.StreamConfig{
-		Name:     "video",
+		Name:     streamName,
 		Subjects: []string{"upload"},
 	})
 	if err != nil {
@@ -43,12 +58,17 @@ func NewNatsAdapter(url string) (ports.EventPublisher, error) {
 	}
 
 	return &NatsAdapter{
-		nc: nc,
-		js: js,
+		nc:             nc,
+		js:             js,
+		closed:         closed,
+		publishTimeout: publishTimeout,
 	}, nil
 }
 
-func (a *NatsAdapter) PublishUploadEvent(videoID int64, filename string) error {
+func (a *NatsAdapter) PublishUploadEvent(ctx context.Context, videoID int64, filename string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.publishTimeout)
+	defer cancel()
+
 	event := uploadEvent{
 		VideoID:  videoID,
 		Filename: filename,
@@ -59,7 +79,7 @@ func (a *NatsAdapter) PublishUploadEvent(videoID int64, filename string) error {
 		return fmt.Errorf("error marshaling event: %w", err)
 	}
 
-	_, err = a.js.Publish("upload", data)
+	_, err = a.js.Publish("upload", data, nats.Context(ctx))
 	if err != nil {
 		return fmt.Errorf("error publishing to NATS: %w", err)
 	}
@@ -67,3 +87,25 @@ func (a *NatsAdapter) PublishUploadEvent(videoID int64, filename string) error {
 	log.Printf("Event published to NATS: video_id=%d, filename=%s", videoID, filename)
 	return nil
 }
+
+// Close drains the connection so publishes already handed to NATS are flushed
+// before it is closed, and blocks until that has finished.
+func (a *NatsAdapter) Close() error {
+	if err := a.nc.Drain(); err != nil {
+		return fmt.Errorf("error draining NATS connection: %w", err)
+	}
+	<-a.closed
+	return nil
+}
+
+// CheckHealth verifies the connection is up and the JetStream stream is reachable,
+// since publishes fail without it even while the connection itself is healthy.
+func (a *NatsAdapter) CheckHealth(ctx context.Context) error {
+	if status := a.nc.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("NATS connection is %s", status)
+	}
+	if _, err := a.js.StreamInfo(streamName, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("JetStream stream %q unavailable: %w", streamName, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID = "video-processor"
+	testCode     = "authorization-code"
+)
+
+// testIssuer is an IdP serving discovery, its JWKS and a token endpoint that answers the
+// code exchange with an ID token built from claims
+type testIssuer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims func(nonce string) map[string]any
+	// challenge is the PKCE code_challenge of the last authorization URL
+	challenge string
+	nonce     string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer := &testIssuer{key: key}
+	issuer.claims = func(nonce string) map[string]any { return issuer.defaultClaims(nonce) }
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                issuer.URL,
+			"authorization_endpoint":                issuer.URL + "/authorize",
+			"token_endpoint":                        issuer.URL + "/token",
+			"jwks_uri":                              issuer.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if r.PostFormValue("code") != testCode || base64.RawURLEncoding.EncodeToString(verifier[:]) != issuer.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     issuer.sign(t, issuer.claims(issuer.nonce)),
+		})
+	})
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+func (i *testIssuer) defaultClaims(nonce string) map[string]any {
+	return map[string]any{
+		"iss":            i.URL,
+		"sub":            "user-123",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "User",
+	}
+}
+
+// sign encodes claims as a compact RS256 JWT
+func (i *testIssuer) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "test-key", "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// authorize follows the authorization URL the way the IdP would, recording the PKCE
+// challenge and nonce it carries
+func (i *testIssuer) authorize(t *testing.T, provider *oidcProvider, nonce, verifier string) {
+	t.Helper()
+	authURL, err := url.Parse(provider.AuthCodeURL("state", nonce, verifier))
+	require.NoError(t, err)
+	query := authURL.Query()
+	assert.Equal(t, i.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, "state", query.Get("state"))
+	i.challenge = query.Get("code_challenge")
+	i.nonce = query.Get("nonce")
+}
+
+func newTestProvider(t *testing.T, issuer *testIssuer) *oidcProvider {
+	t.Helper()
+	provider, err := NewOIDCProvider(issuer.URL, testClientID, "secret", "http://localhost/auth/oidc/callback")
+	require.NoError(t, err)
+	return provider.(*oidcProvider)
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	t.Run("code and PKCE verifier yield the identity", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		provider := newTestProvider(t, issuer)
+		issuer.authorize(t, provider, "nonce-1", "verifier-with-enough-entropy-0123456789")
+
+		identity, err := provider.Exchange(context.Background(), testCode, "verifier-with-enough-entropy-0123456789", "nonce-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, issuer.URL, identity.Issuer)
+		assert.Equal(t, "user-123", identity.Subject)
+		assert.Equal(t, "user@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+		assert.Equal(t, "User", identity.Name)
+	})
+
+	t.Run("wrong PKCE verifier is refused by the IdP", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		provider := newTestProvider(t, issuer)
+		issuer.authorize(t, provider, "nonce-1", "verifier-with-enough-entropy-0123456789")
+
+		_, err := provider.Exchange(context.Background(), testCode, "another-verifier-0123456789-0123456789", "nonce-1")
+
+		assert.ErrorContains(t, err, "invalid_grant")
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		provider := newTestProvider(t, issuer)
+		issuer.authorize(t, provider, "nonce-1", "verifier-with-enough-entropy-0123456789")
+
+		_, err := provider.Exchange(context.Background(), testCode, "verifier-with-enough-entropy-0123456789", "nonce-of-another-login")
+
+		assert.ErrorContains(t, err, "nonce mismatch")
+	})
+
+	t.Run("token for another audience", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		issuer.claims = func(nonce string) map[string]any {
+			claims := issuer.defaultClaims(nonce)
+			claims["aud"] = "another-client"
+			return claims
+		}
+		provider := newTestProvider(t, issuer)
+		issuer.authorize(t, provider, "nonce-1", "verifier-with-enough-entropy-0123456789")
+
+		_, err := provider.Exchange(context.Background(), testCode, "verifier-with-enough-entropy-0123456789", "nonce-1")
+
+		assert.ErrorContains(t, err, "expected audience")
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		issuer.claims = func(nonce string) map[string]any {
+			claims := issuer.defaultClaims(nonce)
+			claims["iat"] = time.Now().Add(-2 * time.Hour).Unix()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return claims
+		}
+		provider := newTestProvider(t, issuer)
+		issuer.authorize(t, provider, "nonce-1", "verifier-with-enough-entropy-0123456789")
+
+		_, err := provider.Exchange(context.Background(), testCode, "verifier-with-enough-entropy-0123456789", "nonce-1")
+
+		assert.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("email_verified sent as a string", func(t *testing.T) {
+		for raw, verified := range map[string]bool{"true": true, "false": false} {
+			issuer := newTestIssuer(t)
+			issuer.claims = func(nonce string) map[string]any {
+				claims := issuer.defaultClaims(nonce)
+				claims["email_verified"] = raw
+				return claims
+			}
+			provider := newTestProvider(t, issuer)
+			issuer.authorize(t, provider, "nonce-1", "verifier-with-enough-entropy-0123456789")
+
+			identity, err := provider.Exchange(context.Background(), testCode, "verifier-with-enough-entropy-0123456789", "nonce-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, verified, identity.EmailVerified, raw)
+		}
+	})
+}
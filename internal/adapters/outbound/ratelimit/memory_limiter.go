@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+// sweepInterval is how often buckets that have refilled completely are dropped
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	period  time.Duration
+}
+
+// memoryRateLimiter keeps token buckets in process memory, so limits apply per instance
+type memoryRateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryRateLimiter() ports.RateLimiter {
+	return &memoryRateLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *memoryRateLimiter) Allow(ctx context.Context, key string, policy domain.RateLimitPolicy) (domain.RateLimitDecision, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.RateLimitDecision{}, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	capacity := float64(policy.Burst)
+	rate := capacity / policy.Period.Seconds() // Tokens per second
+
+	b, ok := l.buckets[policy.Name+"|"+key]
+	if !ok {
+		b = &bucket{tokens: capacity}
+		l.buckets[policy.Name+"|"+key] = b
+	} else {
+		b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	}
+	b.updated = now
+	b.period = policy.Period
+
+	decision := domain.RateLimitDecision{Limit: policy.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.ResetAfter = secondsToDuration((capacity - b.tokens) / rate)
+	return decision, nil
+}
+
+// sweep drops buckets idle long enough to be full again; they are recreated full on demand
+func (l *memoryRateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= b.period {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = domain.RateLimitPolicy{Name: "test", Burst: 2, Period: 10 * time.Second}
+
+// newTestLimiter returns a limiter whose clock only moves through the returned advance
+func newTestLimiter() (*memoryRateLimiter, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewMemoryRateLimiter().(*memoryRateLimiter)
+	l.now = func() time.Time { return now }
+	l.lastSweep = now
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func allow(t *testing.T, l *memoryRateLimiter, key string) domain.RateLimitDecision {
+	t.Helper()
+	decision, err := l.Allow(context.Background(), key, testPolicy)
+	require.NoError(t, err)
+	return decision
+}
+
+func TestMemoryRateLimiter_Allow(t *testing.T) {
+	t.Run("burst is allowed then rejected with Retry-After", func(t *testing.T) {
+		l, _ := newTestLimiter()
+
+		first := allow(t, l, "ip:1")
+		second := allow(t, l, "ip:1")
+		third := allow(t, l, "ip:1")
+
+		assert.True(t, first.Allowed)
+		assert.Equal(t, 1, first.Remaining)
+		assert.True(t, second.Allowed)
+		assert.Equal(t, 0, second.Remaining)
+		assert.False(t, third.Allowed)
+		assert.Equal(t, 2, third.Limit)
+		// One token refills every Period/Burst
+		assert.Equal(t, 5*time.Second, third.RetryAfter)
+		assert.Equal(t, 10*time.Second, third.ResetAfter)
+	})
+
+	t.Run("tokens refill with time", func(t *testing.T) {
+		l, advance := newTestLimiter()
+		allow(t, l, "ip:1")
+		allow(t, l, "ip:1")
+
+		advance(4 * time.Second)
+		rejected := allow(t, l, "ip:1")
+		advance(time.Second)
+		allowed := allow(t, l, "ip:1")
+
+		assert.False(t, rejected.Allowed)
+		assert.Equal(t, time.Second, rejected.RetryAfter)
+		assert.True(t, allowed.Allowed)
+		assert.Equal(t, 0, allowed.Remaining)
+	})
+
+	t.Run("refill is capped at the burst", func(t *testing.T) {
+		l, advance := newTestLimiter()
+		allow(t, l, "ip:1")
+
+		advance(time.Hour)
+		decision := allow(t, l, "ip:1")
+
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 1, decision.Remaining)
+	})
+
+	t.Run("keys and policies have separate buckets", func(t *testing.T) {
+		l, _ := newTestLimiter()
+		allow(t, l, "ip:1")
+		allow(t, l, "ip:1")
+
+		other, err := l.Allow(context.Background(), "ip:1", domain.RateLimitPolicy{Name: "other", Burst: 1, Period: time.Second})
+		require.NoError(t, err)
+
+		assert.True(t, allow(t, l, "ip:2").Allowed)
+		assert.True(t, other.Allowed)
+		assert.False(t, allow(t, l, "ip:1").Allowed)
+	})
+}
+
+func TestMemoryRateLimiter_Sweep(t *testing.T) {
+	l, advance := newTestLimiter()
+	allow(t, l, "ip:idle")
+	advance(55 * time.Second)
+	allow(t, l, "ip:active")
+
+	// Not yet due: sweeps run once per sweepInterval
+	assert.Len(t, l.buckets, 2)
+
+	advance(sweepInterval - 55*time.Second)
+	allow(t, l, "ip:active")
+
+	assert.Len(t, l.buckets, 1)
+	assert.Contains(t, l.buckets, "test|ip:active")
+
+	// A swept bucket comes back full
+	decision := allow(t, l, "ip:idle")
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 1, decision.Remaining)
+}
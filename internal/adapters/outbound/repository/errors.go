@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// The repositories of both drivers report the same domain errors, e.g. ErrEmailTaken, for
+// the constraint violations below
+
+// isPgUniqueViolation reports whether err comes from the unique constraint or index named constraint
+func isPgUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
+}
+
+// isSQLiteUniqueViolation reports whether err comes from a UNIQUE constraint on one of columns
+func isSQLiteUniqueViolation(err error, columns ...string) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code() != sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return false
+	}
+	for _, column := range columns {
+		if strings.Contains(sqliteErr.Error(), column) {
+			return true
+		}
+	}
+	return false
+}
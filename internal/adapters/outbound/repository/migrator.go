@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+var (
+	// ErrSchemaBehind is returned by Check when migrations are pending
+	ErrSchemaBehind = errors.New("schema do banco desatualizado")
+	// ErrSchemaDirty is returned by Check when a migration failed halfway
+	ErrSchemaDirty = errors.New("schema do banco em estado inconsistente (dirty)")
+)
+
+// Migrator applies the migrations found in a directory, e.g. migrations or migrations/sqlite,
+// to one database
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// NewPostgresMigrator opens its own connection to url; Close releases it
+func NewPostgresMigrator(url, dir string) (*Migrator, error) {
+	m, err := migrate.New("file://"+dir, url)
+	if err != nil {
+		return nil, err
+	}
+	return newMigrator(m, dir)
+}
+
+// NewSQLiteMigrator migrates db, which Close also closes
+func NewSQLiteMigrator(db *sql.DB, dir string) (*Migrator, error) {
+	driver, err := migratesqlite.WithInstance(db, &migratesqlite.Config{})
+	if err != nil {
+		return nil, err
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://"+dir, "sqlite", driver)
+	if err != nil {
+		return nil, err
+	}
+	return newMigrator(m, dir)
+}
+
+func newMigrator(m *migrate.Migrate, dir string) (*Migrator, error) {
+	latest, err := latestVersion(dir)
+	if err != nil {
+		m.Close()
+		return nil, err
+	}
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+// latestVersion is the version of the last migration in dir
+func latestVersion(dir string) (uint, error) {
+	src, err := source.Open("file://" + dir)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	for err == nil {
+		var next uint
+		next, err = src.Next(version)
+		if err == nil {
+			version = next
+		}
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	return version, nil
+}
+
+// Up applies the next steps migrations, or all pending ones when steps is 0
+func (m *Migrator) Up(steps int) error {
+	if steps == 0 {
+		return ignoreNoChange(m.m.Up())
+	}
+	return ignoreNoChange(m.m.Steps(steps))
+}
+
+// Down reverts the last steps migrations, or every one when steps is 0
+func (m *Migrator) Down(steps int) error {
+	if steps == 0 {
+		return ignoreNoChange(m.m.Down())
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+// Goto migrates up or down to version
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+// Force sets the version without running any migration and clears the dirty flag, after
+// a failed migration was fixed by hand. -1 marks the database as never migrated.
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Version reports the applied version, 0 when no migration ran, and whether the last
+// migration failed halfway
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Latest is the version the schema has once every migration is applied
+func (m *Migrator) Latest() uint {
+	return m.latest
+}
+
+// Check fails with ErrSchemaDirty or ErrSchemaBehind unless the schema is at the latest
+// version. A newer schema is accepted, so the previous release keeps running during a deploy.
+func (m *Migrator) Check() error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w: a migração %d falhou", ErrSchemaDirty, version)
+	}
+	if version < m.latest {
+		return fmt.Errorf("%w: versão %d, esperada %d", ErrSchemaBehind, version, m.latest)
+	}
+	return nil
+}
+
+// Close releases the connection used for migrating
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
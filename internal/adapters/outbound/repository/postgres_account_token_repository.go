@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresAccountTokenRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresAccountTokenRepository bounds every query with timeout
+func NewPostgresAccountTokenRepository(db *pgxpool.Pool, timeout time.Duration) ports.AccountTokenRepository {
+	return &postgresAccountTokenRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresAccountTokenRepository) Create(ctx context.Context, token *domain.AccountToken) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO account_tokens (user_id, purpose, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+	return err
+}
+
+func (r *postgresAccountTokenRepository) Consume(ctx context.Context, tokenHash, purpose string) (*domain.AccountToken, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// A single UPDATE keeps two concurrent requests from using the same token
+	query := `
+		UPDATE account_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL
+		RETURNING id, user_id, purpose, token_hash, expires_at, used_at, created_at
+	`
+	token := &domain.AccountToken{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, tokenHash, purpose).
+		Scan(&token.ID, &token.UserID, &token.Purpose, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+func (r *postgresAccountTokenRepository) InvalidateForUser(ctx context.Context, userID int64, purpose string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE account_tokens SET used_at = NOW() WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`
+	_, err := conn(ctx, r.db).Exec(ctx, query, userID, purpose)
+	return err
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresAPIKeyRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresAPIKeyRepository bounds every query with timeout
+func NewPostgresAPIKeyRepository(db *pgxpool.Pool, timeout time.Duration) ports.APIKeyRepository {
+	return &postgresAPIKeyRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, key.UserID, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.ExpiresAt).
+		Scan(&key.ID, &key.CreatedAt)
+	return err
+}
+
+func (r *postgresAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys WHERE prefix = $1`
+	key := &domain.APIKey{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, prefix).
+		Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.KeyHash, &key.Scopes, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return key, err
+}
+
+func (r *postgresAPIKeyRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		var k domain.APIKey
+		err := rows.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.KeyHash, &k.Scopes, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (r *postgresAPIKeyRepository) Revoke(ctx context.Context, userID, keyID int64) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, keyID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresAPIKeyRepository) TouchLastUsed(ctx context.Context, keyID int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`
+	_, err := conn(ctx, r.db).Exec(ctx, query, keyID)
+	return err
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresAuditRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresAuditRepository bounds every query with timeout
+func NewPostgresAuditRepository(db *pgxpool.Pool, timeout time.Duration) ports.AuditRepository {
+	return &postgresAuditRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresAuditRepository) Record(ctx context.Context, event *domain.AuditEvent) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	details := event.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	if event.Outcome == "" {
+		event.Outcome = domain.AuditOutcomeSuccess
+	}
+
+	query := `
+		INSERT INTO audit_events (action, outcome, actor_id, target, ip, user_agent, request_id, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, event.Action, event.Outcome, event.ActorID, event.Target, event.IP,
+		event.UserAgent, event.RequestID, details).
+		Scan(&event.ID, &event.CreatedAt)
+	return err
+}
+
+func (r *postgresAuditRepository) Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Action != "" {
+		where("action = $%d", filter.Action)
+	}
+	if filter.Outcome != "" {
+		where("outcome = $%d", filter.Outcome)
+	}
+	if filter.ActorID != nil {
+		where("actor_id = $%d", *filter.ActorID)
+	}
+	if filter.Target != "" {
+		where("target = $%d", filter.Target)
+	}
+	if filter.IP != "" {
+		where("ip = $%d", filter.IP)
+	}
+	if filter.RequestID != "" {
+		where("request_id = $%d", filter.RequestID)
+	}
+	if filter.From != nil {
+		where("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		where("created_at < $%d", *filter.To)
+	}
+
+	query := `SELECT id, action, outcome, actor_id, target, ip, user_agent, request_id, details, created_at FROM audit_events`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.AuditEvent
+	for rows.Next() {
+		var e domain.AuditEvent
+		if err := rows.Scan(&e.ID, &e.Action, &e.Outcome, &e.ActorID, &e.Target, &e.IP, &e.UserAgent, &e.RequestID, &e.Details, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (r *postgresAuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lifts the append-only trigger for this transaction only
+	if _, err := tx.Exec(ctx, `SET LOCAL audit.retention_purge = 'on'`); err != nil {
+		return 0, err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM audit_events WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), tx.Commit(ctx)
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresDataExportRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresDataExportRepository bounds every query with timeout
+func NewPostgresDataExportRepository(db *pgxpool.Pool, timeout time.Duration) ports.DataExportRepository {
+	return &postgresDataExportRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+const dataExportColumns = `id, user_id, status, COALESCE(file_name, ''), COALESCE(token_hash, ''), COALESCE(error, ''), expires_at, completed_at, created_at`
+
+func scanDataExport(row pgx.Row) (*domain.DataExport, error) {
+	e := &domain.DataExport{}
+	err := row.Scan(&e.ID, &e.UserID, &e.Status, &e.FileName, &e.TokenHash, &e.Error, &e.ExpiresAt, &e.CompletedAt, &e.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return e, err
+}
+
+func (r *postgresDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO data_exports (user_id, status, created_at)
+		VALUES ($1, $2, NOW())
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, export.UserID, export.Status).Scan(&export.ID, &export.CreatedAt)
+	return err
+}
+
+func (r *postgresDataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		UPDATE data_exports
+		SET status = $1, file_name = NULLIF($2, ''), token_hash = NULLIF($3, ''), error = NULLIF($4, ''), expires_at = $5, completed_at = $6
+		WHERE id = $7
+	`
+	_, err := conn(ctx, r.db).Exec(ctx, query, export.Status, export.FileName, export.TokenHash, export.Error, export.ExpiresAt, export.CompletedAt, export.ID)
+	return err
+}
+
+func (r *postgresDataExportRepository) GetByID(ctx context.Context, id int64) (*domain.DataExport, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE id = $1`
+	return scanDataExport(conn(ctx, r.db).QueryRow(ctx, query, id))
+}
+
+func (r *postgresDataExportRepository) GetPendingByUserID(ctx context.Context, userID int64) (*domain.DataExport, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE user_id = $1 AND status = 'PENDING' ORDER BY created_at DESC LIMIT 1`
+	return scanDataExport(conn(ctx, r.db).QueryRow(ctx, query, userID))
+}
+
+func (r *postgresDataExportRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DataExport, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE token_hash = $1`
+	return scanDataExport(conn(ctx, r.db).QueryRow(ctx, query, tokenHash))
+}
+
+func (r *postgresDataExportRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.DataExport, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE user_id = $1 FOR UPDATE`
+	return r.queryExports(ctx, query, userID)
+}
+
+func (r *postgresDataExportRepository) ListExpired(ctx context.Context, expiredBefore, failedBefore time.Time) ([]domain.DataExport, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Failed exports never get an expires_at
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE expires_at < $1 OR (status = 'FAILED' AND created_at < $2)`
+	return r.queryExports(ctx, query, expiredBefore, failedBefore)
+}
+
+func (r *postgresDataExportRepository) FailPendingBefore(ctx context.Context, createdBefore time.Time, reason string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE data_exports SET status = 'FAILED', error = $2 WHERE status = 'PENDING' AND created_at < $1`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, createdBefore, reason)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *postgresDataExportRepository) queryExports(ctx context.Context, query string, args ...interface{}) ([]domain.DataExport, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exports []domain.DataExport
+	for rows.Next() {
+		e, err := scanDataExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, *e)
+	}
+	return exports, nil
+}
+
+func (r *postgresDataExportRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `DELETE FROM data_exports WHERE id = $1`
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresHealthChecker struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresHealthChecker(db *pgxpool.Pool) ports.HealthChecker {
+	return &postgresHealthChecker{
+		db: db,
+	}
+}
+
+// CheckHealth acquires a connection and round-trips to the server
+func (c *postgresHealthChecker) CheckHealth(ctx context.Context) error {
+	return c.db.Ping(ctx)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresLoginThrottleRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresLoginThrottleRepository bounds every query with timeout
+func NewPostgresLoginThrottleRepository(db *pgxpool.Pool, timeout time.Duration) ports.LoginThrottleRepository {
+	return &postgresLoginThrottleRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresLoginThrottleRepository) Get(ctx context.Context, scope, subject string) (*domain.LoginThrottle, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT scope, subject, failures, last_failed_at, locked_until FROM login_throttles WHERE scope = $1 AND subject = $2`
+	t := &domain.LoginThrottle{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, scope, subject).
+		Scan(&t.Scope, &t.Subject, &t.Failures, &t.LastFailedAt, &t.LockedUntil)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return t, err
+}
+
+func (r *postgresLoginThrottleRepository) RegisterFailure(ctx context.Context, scope, subject string, resetBefore time.Time) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Upsert in one statement so concurrent failures are all counted
+	query := `
+		INSERT INTO login_throttles (scope, subject, failures, last_failed_at)
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (scope, subject) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failed_at < $3 THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failed_at = NOW()
+		RETURNING failures
+	`
+	var failures int
+	err := conn(ctx, r.db).QueryRow(ctx, query, scope, subject, resetBefore).Scan(&failures)
+	return failures, err
+}
+
+func (r *postgresLoginThrottleRepository) Lock(ctx context.Context, scope, subject string, until time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE login_throttles SET locked_until = $3 WHERE scope = $1 AND subject = $2`
+	_, err := conn(ctx, r.db).Exec(ctx, query, scope, subject, until)
+	return err
+}
+
+func (r *postgresLoginThrottleRepository) Reset(ctx context.Context, scope, subject string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `DELETE FROM login_throttles WHERE scope = $1 AND subject = $2`
+	_, err := conn(ctx, r.db).Exec(ctx, query, scope, subject)
+	return err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresMFARepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresMFARepository bounds every query with timeout
+func NewPostgresMFARepository(db *pgxpool.Pool, timeout time.Duration) ports.MFARepository {
+	return &postgresMFARepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresMFARepository) GetByUserID(ctx context.Context, userID int64) (*domain.UserMFA, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT user_id, secret, confirmed_at, last_used_step, created_at FROM user_mfa WHERE user_id = $1`
+	mfa := &domain.UserMFA{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, userID).
+		Scan(&mfa.UserID, &mfa.Secret, &mfa.ConfirmedAt, &mfa.LastUsedStep, &mfa.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return mfa, err
+}
+
+func (r *postgresMFARepository) SavePending(ctx context.Context, mfa *domain.UserMFA) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Never overwrites a confirmed secret
+	query := `
+		INSERT INTO user_mfa (user_id, secret, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_mfa.confirmed_at IS NULL
+		RETURNING created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, mfa.UserID, mfa.Secret).Scan(&mfa.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return domain.ErrMFAAlreadyEnabled
+	}
+	return err
+}
+
+func (r *postgresMFARepository) Confirm(ctx context.Context, userID int64, recoveryCodeHashes []string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE user_mfa SET confirmed_at = NOW() WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.Exec(ctx, `INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *postgresMFARepository) MarkStepUsed(ctx context.Context, userID, step int64) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE user_mfa SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresMFARepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE mfa_recovery_codes SET used_at = NOW() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresMFARepository) Delete(ctx context.Context, userID int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
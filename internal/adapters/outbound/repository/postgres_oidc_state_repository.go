@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresOIDCStateRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresOIDCStateRepository bounds every query with timeout
+func NewPostgresOIDCStateRepository(db *pgxpool.Pool, timeout time.Duration) ports.OIDCStateRepository {
+	return &postgresOIDCStateRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresOIDCStateRepository) Create(ctx context.Context, state *domain.OIDCLoginState) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO oidc_login_states (state_hash, nonce, code_verifier, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, state.StateHash, state.Nonce, state.CodeVerifier, state.ExpiresAt).
+		Scan(&state.ID, &state.CreatedAt)
+	return err
+}
+
+func (r *postgresOIDCStateRepository) Consume(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		DELETE FROM oidc_login_states WHERE state_hash = $1
+		RETURNING id, state_hash, nonce, code_verifier, expires_at, created_at
+	`
+	state := &domain.OIDCLoginState{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, stateHash).
+		Scan(&state.ID, &state.StateHash, &state.Nonce, &state.CodeVerifier, &state.ExpiresAt, &state.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return state, err
+}
+
+func (r *postgresOIDCStateRepository) DeleteExpired(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM oidc_login_states WHERE expires_at < NOW()`)
+	return err
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresOrganizationRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresOrganizationRepository bounds every query with timeout
+func NewPostgresOrganizationRepository(db *pgxpool.Pool, timeout time.Duration) ports.OrganizationRepository {
+	return &postgresOrganizationRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresOrganizationRepository) Create(ctx context.Context, org *domain.Organization, ownerID int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Joins the unit of work running in ctx, if any
+	return NewPostgresUnitOfWork(r.db).Do(ctx, func(ctx context.Context) error {
+		err := conn(ctx, r.db).QueryRow(ctx, `INSERT INTO organizations (name, created_at) VALUES ($1, NOW()) RETURNING id, created_at`, org.Name).
+			Scan(&org.ID, &org.CreatedAt)
+		if err != nil {
+			return err
+		}
+		query := `INSERT INTO organization_members (organization_id, user_id, role, created_at) VALUES ($1, $2, $3, NOW())`
+		_, err = conn(ctx, r.db).Exec(ctx, query, org.ID, ownerID, domain.OrgRoleOwner)
+		return err
+	})
+}
+
+func (r *postgresOrganizationRepository) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, name, created_at FROM organizations WHERE id = $1`
+	org := &domain.Organization{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(&org.ID, &org.Name, &org.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return org, err
+}
+
+func (r *postgresOrganizationRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.Organization, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		SELECT o.id, o.name, m.role, o.created_at
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []domain.Organization
+	for rows.Next() {
+		var o domain.Organization
+		if err := rows.Scan(&o.ID, &o.Name, &o.Role, &o.CreatedAt); err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, o)
+	}
+	return orgs, nil
+}
+
+func (r *postgresOrganizationRepository) GetMember(ctx context.Context, organizationID, userID int64) (*domain.OrganizationMember, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		SELECT m.organization_id, m.user_id, u.email, u.name, m.role, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1 AND m.user_id = $2
+	`
+	member := &domain.OrganizationMember{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, organizationID, userID).
+		Scan(&member.OrganizationID, &member.UserID, &member.Email, &member.Name, &member.Role, &member.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return member, err
+}
+
+func (r *postgresOrganizationRepository) ListMembers(ctx context.Context, organizationID int64) ([]domain.OrganizationMember, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		SELECT m.organization_id, m.user_id, u.email, u.name, m.role, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1
+		ORDER BY m.created_at
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []domain.OrganizationMember
+	for rows.Next() {
+		var m domain.OrganizationMember
+		if err := rows.Scan(&m.OrganizationID, &m.UserID, &m.Email, &m.Name, &m.Role, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func (r *postgresOrganizationRepository) AddMember(ctx context.Context, member *domain.OrganizationMember) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+		RETURNING created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, member.OrganizationID, member.UserID, member.Role).Scan(&member.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return domain.ErrAlreadyMember
+	}
+	return err
+}
+
+func (r *postgresOrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, organizationID, userID, role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *postgresOrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *postgresOrganizationRepository) CountOwners(ctx context.Context, organizationID int64) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Aggregates cannot take row locks, so the owners are locked and counted here. Taking them
+	// in a fixed order keeps two owners changing at once from deadlocking.
+	query := `SELECT user_id FROM organization_members WHERE organization_id = $1 AND role = $2 ORDER BY user_id FOR UPDATE`
+	rows, err := conn(ctx, r.db).Query(ctx, query, organizationID, domain.OrgRoleOwner)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
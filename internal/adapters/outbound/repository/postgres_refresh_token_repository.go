@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresRefreshTokenRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresRefreshTokenRepository bounds every query with timeout
+func NewPostgresRefreshTokenRepository(db *pgxpool.Pool, timeout time.Duration) ports.RefreshTokenRepository {
+	return &postgresRefreshTokenRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, authenticated_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt, token.AuthenticatedAt).
+		Scan(&token.ID, &token.CreatedAt)
+	return err
+}
+
+func (r *postgresRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, user_id, family_id, token_hash, expires_at, rotated_at, revoked_at, created_at, authenticated_at FROM refresh_tokens WHERE token_hash = $1`
+	token := &domain.RefreshToken{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, tokenHash).
+		Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.ExpiresAt, &token.RotatedAt, &token.RevokedAt, &token.CreatedAt, &token.AuthenticatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+func (r *postgresRefreshTokenRepository) MarkRotated(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE refresh_tokens SET rotated_at = NOW() WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL`
+	tag, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *postgresRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`
+	_, err := conn(ctx, r.db).Exec(ctx, query, familyID)
+	return err
+}
+
+func (r *postgresRefreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT EXISTS (SELECT 1 FROM refresh_tokens WHERE family_id = $1 AND revoked_at IS NOT NULL)`
+	var revoked bool
+	err := conn(ctx, r.db).QueryRow(ctx, query, familyID).Scan(&revoked)
+	return revoked, err
+}
+
+func (r *postgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID int64, keepFamilyID string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND family_id <> $2 AND revoked_at IS NULL`
+	_, err := conn(ctx, r.db).Exec(ctx, query, userID, keepFamilyID)
+	return err
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+	"video-processor/internal/adapters/outbound/repositorytest"
+	"video-processor/internal/core/domain"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const postgresTestMigrations = "../../../../migrations"
+
+// testPostgres is the database shared by the Postgres tests of this package. It is started
+// on first use and stopped by TestMain once every test has run.
+var testPostgres struct {
+	once   sync.Once
+	url    string
+	server *embeddedpostgres.EmbeddedPostgres
+	// unavailable is why the embedded Postgres could not be downloaded or started
+	unavailable error
+	err         error
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testPostgres.server != nil {
+		testPostgres.server.Stop()
+	}
+	os.Exit(code)
+}
+
+// startTestPostgres returns the URL of TEST_DATABASE_URL when set, or else of an ephemeral
+// Postgres whose binaries are downloaded on the first run and cached in the user cache dir.
+func startTestPostgres() (string, error) {
+	if url := os.Getenv("TEST_DATABASE_URL"); url != "" {
+		return url, nil
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	port := uint32(listener.Addr().(*net.TCPAddr).Port)
+	listener.Close()
+
+	runtime, err := os.MkdirTemp("", "video-processor-postgres-")
+	if err != nil {
+		return "", err
+	}
+	config := embeddedpostgres.DefaultConfig().
+		Version(embeddedpostgres.V16).
+		Port(port).
+		RuntimePath(runtime).
+		StartTimeout(time.Minute).
+		Logger(io.Discard)
+	if cache, err := os.UserCacheDir(); err == nil {
+		config = config.CachePath(cache + "/embedded-postgres-go")
+	}
+
+	server := embeddedpostgres.NewDatabase(config)
+	if err := server.Start(); err != nil {
+		os.RemoveAll(runtime)
+		return "", err
+	}
+	testPostgres.server = server
+	return config.GetConnectionURL() + "?sslmode=disable", nil
+}
+
+// newTestPostgres migrates the test database and empties it, so never point TEST_DATABASE_URL
+// at a database with data worth keeping. Without TEST_DATABASE_URL the test is skipped when
+// the embedded Postgres cannot be downloaded or started, e.g. offline or as root, which
+// Postgres refuses to run as. Any other failure, migrations included, fails the test.
+func newTestPostgres(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Postgres tests skipped in short mode")
+	}
+
+	testPostgres.once.Do(func() {
+		url, err := startTestPostgres()
+		if err != nil {
+			if os.Getenv("TEST_DATABASE_URL") != "" {
+				testPostgres.err = err
+			} else {
+				testPostgres.unavailable = err
+			}
+			return
+		}
+		testPostgres.url = url
+		migrator, err := NewPostgresMigrator(url, postgresTestMigrations)
+		if err != nil {
+			testPostgres.err = err
+			return
+		}
+		defer migrator.Close()
+		if err := migrator.Up(0); err != nil {
+			testPostgres.err = fmt.Errorf("migrations: %w", err)
+		}
+	})
+	if testPostgres.unavailable != nil {
+		t.Skipf("embedded Postgres unavailable, set TEST_DATABASE_URL to run against a server: %v", testPostgres.unavailable)
+	}
+	if testPostgres.err != nil {
+		t.Fatalf("Postgres test database: %v", testPostgres.err)
+	}
+
+	db, err := pgxpool.New(context.Background(), testPostgres.url)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	truncatePostgres(t, db)
+	return db
+}
+
+func truncatePostgres(t *testing.T, db *pgxpool.Pool) {
+	t.Helper()
+	_, err := db.Exec(context.Background(), `TRUNCATE users, organizations RESTART IDENTITY CASCADE`)
+	require.NoError(t, err)
+}
+
+func TestPostgresRepositories(t *testing.T) {
+	db := newTestPostgres(t)
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		truncatePostgres(t, db)
+
+		return repositorytest.Repositories{
+			Users:         NewPostgresUserRepository(db, 5*time.Second),
+			Videos:        NewPostgresVideoRepository(db, 5*time.Second),
+			Organizations: NewPostgresOrganizationRepository(db, 0),
+			UnitOfWork:    NewPostgresUnitOfWork(db),
+		}
+	})
+}
+
+// Rows written before the repositories filled in every column, or by hand, hold NULLs
+// that every read must still be able to scan
+func TestPostgresVideoRepository_NullColumns(t *testing.T) {
+	db := newTestPostgres(t)
+	ctx := context.Background()
+	users := NewPostgresUserRepository(db, 5*time.Second)
+	videos := NewPostgresVideoRepository(db, 5*time.Second)
+
+	user := &domain.User{Email: "null@example.com", Password: "hash", Name: "Null", Role: domain.RoleUser}
+	require.NoError(t, users.Create(ctx, user))
+
+	var id int64
+	err := db.QueryRow(ctx, `
+		INSERT INTO videos (user_id, original_filename, storage_key, status, zip_path, frame_count, message)
+		VALUES ($1, 'clip.mp4', 'null-key.mp4', $2, NULL, NULL, NULL)
+		RETURNING id
+	`, user.ID, domain.StatusPending).Scan(&id)
+	require.NoError(t, err)
+
+	want := domain.Video{ID: id, UserID: user.ID, OriginalFilename: "clip.mp4", StorageKey: "null-key.mp4", Status: domain.StatusPending}
+	assertVideo := func(t *testing.T, got domain.Video) {
+		t.Helper()
+		got.CreatedAt, got.UpdatedAt = time.Time{}, time.Time{}
+		assert.Equal(t, want, got)
+	}
+
+	video, err := videos.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, video)
+	assertVideo(t, *video)
+
+	for name, list := range map[string]func() ([]domain.Video, error){
+		"GetByUserID":            func() ([]domain.Video, error) { return videos.GetByUserID(ctx, user.ID) },
+		"ListAccessibleByUserID": func() ([]domain.Video, error) { return videos.ListAccessibleByUserID(ctx, user.ID) },
+		"List":                   func() ([]domain.Video, error) { return videos.List(ctx) },
+	} {
+		got, err := list()
+		require.NoError(t, err, name)
+		require.Len(t, got, 1, name)
+		assertVideo(t, got[0])
+	}
+
+	history, err := videos.GetStatusHistoryByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.StatusPending, history[0].Status)
+	assert.Empty(t, history[0].Message)
+}
+
+// Two owners demoting each other must not both count the other as still an owner
+func TestPostgresOrganizationRepository_CountOwnersLocks(t *testing.T) {
+	db := newTestPostgres(t)
+	ctx := context.Background()
+	users := NewPostgresUserRepository(db, 5*time.Second)
+	orgs := NewPostgresOrganizationRepository(db, 5*time.Second)
+	uow := NewPostgresUnitOfWork(db)
+
+	first := &domain.User{Email: "first@example.com", Password: "hash", Name: "First", Role: domain.RoleUser}
+	second := &domain.User{Email: "second@example.com", Password: "hash", Name: "Second", Role: domain.RoleUser}
+	require.NoError(t, users.Create(ctx, first))
+	require.NoError(t, users.Create(ctx, second))
+	team := &domain.Organization{Name: "Team"}
+	require.NoError(t, orgs.Create(ctx, team, first.ID))
+	require.NoError(t, orgs.AddMember(ctx, &domain.OrganizationMember{OrganizationID: team.ID, UserID: second.ID, Role: domain.OrgRoleOwner}))
+
+	locked, release := make(chan struct{}), make(chan struct{})
+	demoted := make(chan error, 1)
+	go func() {
+		demoted <- uow.Do(ctx, func(ctx context.Context) error {
+			if _, err := orgs.CountOwners(ctx, team.ID); err != nil {
+				return err
+			}
+			if err := orgs.UpdateMemberRole(ctx, team.ID, first.ID, domain.OrgRoleViewer); err != nil {
+				return err
+			}
+			close(locked)
+			<-release
+			return nil
+		})
+	}()
+	<-locked
+
+	counted := make(chan int, 1)
+	go func() {
+		uow.Do(ctx, func(ctx context.Context) error {
+			owners, err := orgs.CountOwners(ctx, team.ID)
+			counted <- owners
+			return err
+		})
+	}()
+
+	select {
+	case <-counted:
+		t.Fatal("owners counted while another unit of work holds them")
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(release)
+	require.NoError(t, <-demoted)
+	assert.Equal(t, 1, <-counted)
+}
+
+// Runs the down migrations on the shared database, leaving it fully migrated again
+func TestPostgresMigrator(t *testing.T) {
+	db := newTestPostgres(t)
+	migrator, err := NewPostgresMigrator(testPostgres.url, postgresTestMigrations)
+	require.NoError(t, err)
+	defer migrator.Close()
+	require.NoError(t, migrator.Check())
+
+	require.NoError(t, migrator.Down(0))
+	var tables int
+	require.NoError(t, db.QueryRow(context.Background(), `
+		SELECT COUNT(*) FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_name <> 'schema_migrations'
+	`).Scan(&tables))
+	assert.Zero(t, tables)
+
+	require.NoError(t, migrator.Up(0))
+	require.NoError(t, migrator.Check())
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// querier is satisfied by both the pool and a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// conn returns the transaction of the unit of work running in ctx, or the pool outside of one
+func conn(ctx context.Context, db *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+type postgresUnitOfWork struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresUnitOfWork(db *pgxpool.Pool) ports.UnitOfWork {
+	return &postgresUnitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn in a transaction. A nested call becomes a savepoint of the outer transaction,
+// so only its own changes are undone when it fails.
+func (u *postgresUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	var tx pgx.Tx
+	var err error
+	if outer, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = u.db.Begin(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	// Rolling back after a commit is a no-op; the rollback must still run when ctx was cancelled
+	defer tx.Rollback(context.WithoutCancel(ctx))
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
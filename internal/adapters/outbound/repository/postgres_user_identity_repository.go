@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresUserIdentityRepository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+// NewPostgresUserIdentityRepository bounds every query with timeout
+func NewPostgresUserIdentityRepository(db *pgxpool.Pool, timeout time.Duration) ports.UserIdentityRepository {
+	return &postgresUserIdentityRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *postgresUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO user_identities (user_id, issuer, subject, email, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`
+	err := conn(ctx, r.db).QueryRow(ctx, query, identity.UserID, identity.Issuer, identity.Subject, identity.Email).
+		Scan(&identity.ID, &identity.CreatedAt)
+	return err
+}
+
+func (r *postgresUserIdentityRepository) GetBySubject(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, user_id, issuer, subject, email, created_at FROM user_identities WHERE issuer = $1 AND subject = $2`
+	identity := &domain.UserIdentity{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, issuer, subject).
+		Scan(&identity.ID, &identity.UserID, &identity.Issuer, &identity.Subject, &identity.Email, &identity.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return identity, err
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"video-processor/internal/core/domain"
 	"video-processor/internal/core/ports"
 
@@ -10,41 +11,124 @@ import (
 )
 
 type postgresUserRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	timeout time.Duration
 }
 
-func NewPostgresUserRepository(db *pgxpool.Pool) ports.UserRepository {
+// NewPostgresUserRepository bounds every query with timeout
+func NewPostgresUserRepository(db *pgxpool.Pool, timeout time.Duration) ports.UserRepository {
 	return &postgresUserRepository{
-		db: db,
+		db:      db,
+		timeout: timeout,
 	}
 }
 
-func (r *postgresUserRepository) Create(user *domain.User) error {
+func (r *postgresUserRepository) Create(ctx context.Context, user *domain.User) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `
-		INSERT INTO users (email, password, name, created_at)
-		VALUES ($1, $2, $3, NOW())
+		INSERT INTO users (email, password, name, role, email_verified, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
 		RETURNING id, created_at
 	`
-	err := r.db.QueryRow(context.Background(), query, user.Email, user.Password, user.Name).Scan(&user.ID, &user.CreatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, query, user.Email, user.Password, user.Name, user.Role, user.EmailVerified).Scan(&user.ID, &user.CreatedAt)
+	if isPgUniqueViolation(err, "users_email_key") {
+		return domain.ErrEmailTaken
+	}
 	return err
 }
 
-func (r *postgresUserRepository) GetByEmail(email string) (*domain.User, error) {
-	query := `SELECT id, email, password, name, created_at FROM users WHERE email = $1`
+func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, email, password, name, role, email_verified, created_at FROM users WHERE email = $1`
 	user := &domain.User{}
-	err := r.db.QueryRow(context.Background(), query, email).Scan(&user.ID, &user.Email, &user.Password, &user.Name, &user.CreatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, query, email).Scan(&user.ID, &user.Email, &user.Password, &user.Name, &user.Role, &user.EmailVerified, &user.CreatedAt)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
 	return user, err
 }
 
-func (r *postgresUserRepository) GetByID(id int64) (*domain.User, error) {
-	query := `SELECT id, email, password, name, created_at FROM users WHERE id = $1`
+func (r *postgresUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, email, password, name, role, email_verified, created_at FROM users WHERE id = $1`
 	user := &domain.User{}
-	err := r.db.QueryRow(context.Background(), query, id).Scan(&user.ID, &user.Email, &user.Password, &user.Name, &user.CreatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(&user.ID, &user.Email, &user.Password, &user.Name, &user.Role, &user.EmailVerified, &user.CreatedAt)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
 	return user, err
 }
+
+func (r *postgresUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT id, email, password, name, role, email_verified, created_at FROM users ORDER BY id`
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		err := rows.Scan(&u.ID, &u.Email, &u.Password, &u.Name, &u.Role, &u.EmailVerified, &u.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (r *postgresUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE users SET password = $1 WHERE id = $2`
+	_, err := conn(ctx, r.db).Exec(ctx, query, passwordHash, id)
+	return err
+}
+
+func (r *postgresUserRepository) MarkEmailVerified(ctx context.Context, id int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE users SET email_verified = TRUE WHERE id = $1`
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+func (r *postgresUserRepository) Update(ctx context.Context, user *domain.User) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE users SET name = $1, email = $2, email_verified = $3 WHERE id = $4`
+	_, err := conn(ctx, r.db).Exec(ctx, query, user.Name, user.Email, user.EmailVerified, user.ID)
+	if isPgUniqueViolation(err, "users_email_key") {
+		return domain.ErrEmailTaken
+	}
+	return err
+}
+
+func (r *postgresUserRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Personal videos go with the account, while organization videos are kept and their
+	// user_id is set to NULL by the foreign key
+	return NewPostgresUnitOfWork(r.db).Do(ctx, func(ctx context.Context) error {
+		if _, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM videos WHERE user_id = $1 AND organization_id IS NULL`, id); err != nil {
+			return err
+		}
+		_, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+		return err
+	})
+}
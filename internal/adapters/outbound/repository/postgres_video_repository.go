@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"video-processor/internal/core/domain"
 	"video-processor/internal/core/ports"
 
@@ -10,65 +11,135 @@ import (
 )
 
 type postgresVideoRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	timeout time.Duration
 }
 
-func NewPostgresVideoRepository(db *pgxpool.Pool) ports.VideoRepository {
+// NewPostgresVideoRepository bounds every query with timeout
+func NewPostgresVideoRepository(db *pgxpool.Pool, timeout time.Duration) ports.VideoRepository {
 	return &postgresVideoRepository{
-		db: db,
+		db:      db,
+		timeout: timeout,
 	}
 }
 
-func (r *postgresVideoRepository) Create(video *domain.Video) error {
+func (r *postgresVideoRepository) Create(ctx context.Context, video *domain.Video) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
 	query := `
-		INSERT INTO videos (user_id, filename, status, created_at, updated_at)
-		VALUES ($1, $2, $3, NOW(), NOW())
+		INSERT INTO videos (user_id, organization_id, original_filename, storage_key, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
-	err := r.db.QueryRow(context.Background(), query, video.UserID, video.Filename, video.Status).
+	err := conn(ctx, r.db).QueryRow(ctx, query, video.UserID, video.OrganizationID, video.OriginalFilename, video.StorageKey, video.Status).
 		Scan(&video.ID, &video.CreatedAt, &video.UpdatedAt)
+	if isPgUniqueViolation(err, "idx_videos_storage_key") {
+		return domain.ErrStorageKeyTaken
+	}
 	return err
 }
 
-func (r *postgresVideoRepository) Update(video *domain.Video) error {
+func (r *postgresVideoRepository) Update(ctx context.Context, video *domain.Video) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	record := newVideoRecord(video)
 	query := `
 		UPDATE videos
-		SET status = $1, zip_path = $2, frame_count = $3, message = $4, updated_at = NOW()
-		WHERE id = $5
+		SET status = $1, zip_path = $2, frame_count = $3, message = $4, error_code = $5, worker_id = $6, output_size = $7,
+			processing_started_at = $8, processing_finished_at = $9, updated_at = NOW()
+		WHERE id = $10
 		RETURNING updated_at
 	`
-	err := r.db.QueryRow(context.Background(), query, video.Status, video.ZipPath, video.FrameCount, video.Message, video.ID).
+	err := conn(ctx, r.db).QueryRow(ctx, query, record.Status, record.ZipPath, record.FrameCount, record.Message, record.ErrorCode, record.WorkerID, record.OutputSize,
+		record.ProcessingStartedAt, record.ProcessingFinishedAt, record.ID).
 		Scan(&video.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return domain.ErrVideoNotFound
+	}
 	return err
 }
 
-func (r *postgresVideoRepository) GetByID(id int64) (*domain.Video, error) {
-	query := `SELECT id, user_id, filename, status, zip_path, frame_count, message, created_at, updated_at FROM videos WHERE id = $1`
-	video := &domain.Video{}
-	err := r.db.QueryRow(context.Background(), query, id).
-		Scan(&video.ID, &video.UserID, &video.Filename, &video.Status, &video.ZipPath, &video.FrameCount, &video.Message, &video.CreatedAt, &video.UpdatedAt)
+func (r *postgresVideoRepository) GetByID(ctx context.Context, id int64) (*domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + videoColumns + ` FROM videos WHERE id = $1`
+	var record videoRecord
+	err := record.scan(conn(ctx, r.db).QueryRow(ctx, query, id))
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
-	return video, err
+	if err != nil {
+		return nil, err
+	}
+	video := record.toDomain()
+	return &video, nil
+}
+
+func (r *postgresVideoRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + videoColumns + ` FROM videos WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+	return r.queryVideos(ctx, query, userID)
 }
 
-func (r *postgresVideoRepository) GetByUserID(userID int64) ([]domain.Video, error) {
-	query := `SELECT id, user_id, filename, status, zip_path, frame_count, message, created_at, updated_at FROM videos WHERE user_id = $1 ORDER BY created_at DESC`
-	rows, err := r.db.Query(context.Background(), query, userID)
+func (r *postgresVideoRepository) ListAccessibleByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		SELECT ` + videoColumns + ` FROM videos
+		WHERE (organization_id IS NULL AND user_id = $1)
+		   OR organization_id IN (SELECT organization_id FROM organization_members WHERE user_id = $1)
+		ORDER BY created_at DESC, id DESC
+	`
+	return r.queryVideos(ctx, query, userID)
+}
+
+func (r *postgresVideoRepository) List(ctx context.Context) ([]domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + videoColumns + ` FROM videos ORDER BY created_at DESC, id DESC`
+	return r.queryVideos(ctx, query)
+}
+
+func (r *postgresVideoRepository) queryVideos(ctx context.Context, query string, args ...interface{}) ([]domain.Video, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanVideos(rows)
+}
+
+func (r *postgresVideoRepository) GetStatusHistoryByUserID(ctx context.Context, userID int64) ([]domain.VideoStatusEvent, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		SELECT h.video_id, h.status, COALESCE(h.message, ''), h.created_at
+		FROM video_status_history h
+		JOIN videos v ON v.id = h.video_id
+		WHERE v.user_id = $1
+		ORDER BY h.created_at, h.id
+	`
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var videos []domain.Video
+	var events []domain.VideoStatusEvent
 	for rows.Next() {
-		var v domain.Video
-		err := rows.Scan(&v.ID, &v.UserID, &v.Filename, &v.Status, &v.ZipPath, &v.FrameCount, &v.Message, &v.CreatedAt, &v.UpdatedAt)
-		if err != nil {
+		var e domain.VideoStatusEvent
+		if err := rows.Scan(&e.VideoID, &e.Status, &e.Message, &e.CreatedAt); err != nil {
 			return nil, err
 		}
-		videos = append(videos, v)
+		events = append(events, e)
 	}
-	return videos, nil
+	return events, nil
 }
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+	"video-processor/internal/core/ports"
+)
+
+// OpenSQLite opens the database file at path, creating it if needed. Foreign keys are
+// enforced, writers wait for each other instead of failing and transactions take the
+// write lock up front, so a unit of work never fails halfway on a busy database.
+func OpenSQLite(path string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Set("_time_format", "sqlite")
+	query.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// MigrateSQLite applies the pending migrations found in dir, e.g. migrations/sqlite
+func MigrateSQLite(db *sql.DB, dir string) error {
+	m, err := NewSQLiteMigrator(db, dir)
+	if err != nil {
+		return err
+	}
+	return m.Up(0)
+}
+
+// NewSQLiteHealthChecker reports the database as down when it cannot be reached
+func NewSQLiteHealthChecker(db *sql.DB) ports.HealthChecker {
+	return ports.HealthCheckFunc(db.PingContext)
+}
+
+// sqlQuerier is satisfied by both the database and a transaction
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type sqliteTxKey struct{}
+
+// sqliteTx is the transaction of a unit of work and how deeply units of work are nested in it
+type sqliteTx struct {
+	tx    *sql.Tx
+	depth int
+}
+
+// sqliteConn returns the transaction of the unit of work running in ctx, or the database outside of one
+func sqliteConn(ctx context.Context, db *sql.DB) sqlQuerier {
+	if t, ok := ctx.Value(sqliteTxKey{}).(*sqliteTx); ok {
+		return t.tx
+	}
+	return db
+}
+
+// sqliteNow is the timestamp written by the SQLite repositories. Postgres fills in NOW()
+// itself; here the time comes from Go so it keeps the same microsecond precision.
+func sqliteNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+type sqliteUnitOfWork struct {
+	db *sql.DB
+}
+
+func NewSQLiteUnitOfWork(db *sql.DB) ports.UnitOfWork {
+	return &sqliteUnitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn in a transaction. A nested call becomes a savepoint of the outer transaction,
+// so only its own changes are undone when it fails.
+func (u *sqliteUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if outer, ok := ctx.Value(sqliteTxKey{}).(*sqliteTx); ok {
+		return u.savepoint(ctx, outer, fn)
+	}
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// Rolling back after a commit is a no-op
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, sqliteTxKey{}, &sqliteTx{tx: tx})); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (u *sqliteUnitOfWork) savepoint(ctx context.Context, outer *sqliteTx, fn func(ctx context.Context) error) error {
+	inner := &sqliteTx{tx: outer.tx, depth: outer.depth + 1}
+	name := fmt.Sprintf("unit_of_work_%d", inner.depth)
+	if _, err := outer.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, sqliteTxKey{}, inner)); err != nil {
+		// The savepoint must be undone even when ctx was cancelled
+		rollbackCtx := context.WithoutCancel(ctx)
+		outer.tx.ExecContext(rollbackCtx, "ROLLBACK TO "+name)
+		outer.tx.ExecContext(rollbackCtx, "RELEASE "+name)
+		return err
+	}
+	_, err := outer.tx.ExecContext(ctx, "RELEASE "+name)
+	return err
+}
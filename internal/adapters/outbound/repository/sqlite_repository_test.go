@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+	"video-processor/internal/adapters/outbound/memory"
+	"video-processor/internal/adapters/outbound/repositorytest"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sqliteTestMigrations = "../../../../migrations/sqlite"
+
+func TestSQLiteRepositories(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db, err := OpenSQLite(filepath.Join(t.TempDir(), "test.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		require.NoError(t, MigrateSQLite(db, sqliteTestMigrations))
+
+		orgs := memory.NewMemoryOrganizationRepository(memory.NewStore())
+		return repositorytest.Repositories{
+			Users:         NewSQLiteUserRepository(db, 5*time.Second),
+			Videos:        NewSQLiteVideoRepository(db, orgs, 5*time.Second),
+			Organizations: orgs,
+			UnitOfWork:    NewSQLiteUnitOfWork(db),
+		}
+	})
+}
+
+func TestSQLiteMigrator(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	migrator, err := NewSQLiteMigrator(db, sqliteTestMigrations)
+	require.NoError(t, err)
+	defer migrator.Close()
+
+	assert.ErrorIs(t, migrator.Check(), ErrSchemaBehind)
+
+	t.Run("down migrations undo every up migration", func(t *testing.T) {
+		require.NoError(t, migrator.Up(0))
+		require.NoError(t, migrator.Check())
+		require.NoError(t, migrator.Down(0))
+
+		var tables int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type IN ('table', 'trigger') AND name NOT IN ('schema_migrations', 'sqlite_sequence')`).Scan(&tables))
+		assert.Zero(t, tables)
+
+		// Applying them again must work on the emptied database
+		require.NoError(t, migrator.Up(0))
+		require.NoError(t, migrator.Check())
+	})
+
+	t.Run("steps and goto", func(t *testing.T) {
+		require.NoError(t, migrator.Down(1))
+		version, dirty, err := migrator.Version()
+		require.NoError(t, err)
+		assert.Equal(t, migrator.Latest()-1, version)
+		assert.False(t, dirty)
+		assert.ErrorIs(t, migrator.Check(), ErrSchemaBehind)
+
+		require.NoError(t, migrator.Goto(1))
+		version, _, err = migrator.Version()
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), version)
+
+		require.NoError(t, migrator.Up(1))
+		version, _, err = migrator.Version()
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), version)
+		require.NoError(t, migrator.Up(0))
+	})
+
+	t.Run("dirty schema until forced", func(t *testing.T) {
+		_, err := db.Exec(`UPDATE schema_migrations SET dirty = TRUE`)
+		require.NoError(t, err)
+		assert.ErrorIs(t, migrator.Check(), ErrSchemaDirty)
+
+		require.NoError(t, migrator.Force(int(migrator.Latest())))
+		assert.NoError(t, migrator.Check())
+	})
+
+	t.Run("data survives a round trip of the last migration", func(t *testing.T) {
+		users := NewSQLiteUserRepository(db, 5*time.Second)
+		user := &domain.User{Email: "user@example.com", Password: "hash", Name: "User", Role: domain.RoleUser}
+		require.NoError(t, users.Create(context.Background(), user))
+		videos := NewSQLiteVideoRepository(db, memory.NewMemoryOrganizationRepository(memory.NewStore()), 5*time.Second)
+		video := &domain.Video{UserID: user.ID, OriginalFilename: "clip.mp4", StorageKey: "clip.mp4", Status: domain.StatusPending}
+		require.NoError(t, videos.Create(context.Background(), video))
+
+		require.NoError(t, migrator.Down(1))
+		require.NoError(t, migrator.Up(0))
+
+		stored, err := users.GetByID(context.Background(), user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, "user@example.com", stored.Email)
+		// Rebuilding the videos table must not take the status history with it
+		history, err := videos.GetStatusHistoryByUserID(context.Background(), user.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, video.ID, history[0].VideoID)
+	})
+}
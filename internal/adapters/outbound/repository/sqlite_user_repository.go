@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+const sqliteUserColumns = `id, email, password, name, role, email_verified, created_at`
+
+func scanSQLiteUser(row interface{ Scan(...any) error }, u *domain.User) error {
+	return row.Scan(&u.ID, &u.Email, &u.Password, &u.Name, &u.Role, &u.EmailVerified, &u.CreatedAt)
+}
+
+type sqliteUserRepository struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+// NewSQLiteUserRepository bounds every query with timeout
+func NewSQLiteUserRepository(db *sql.DB, timeout time.Duration) ports.UserRepository {
+	return &sqliteUserRepository{
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (r *sqliteUserRepository) Create(ctx context.Context, user *domain.User) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO users (email, password, name, role, email_verified, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at
+	`
+	err := sqliteConn(ctx, r.db).QueryRowContext(ctx, query, user.Email, user.Password, user.Name, user.Role, user.EmailVerified, sqliteNow()).
+		Scan(&user.ID, &user.CreatedAt)
+	if isSQLiteUniqueViolation(err, "users.email") {
+		return domain.ErrEmailTaken
+	}
+	return err
+}
+
+func (r *sqliteUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + sqliteUserColumns + ` FROM users WHERE email = ?`
+	user := &domain.User{}
+	err := scanSQLiteUser(sqliteConn(ctx, r.db).QueryRowContext(ctx, query, email), user)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *sqliteUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + sqliteUserColumns + ` FROM users WHERE id = ?`
+	user := &domain.User{}
+	err := scanSQLiteUser(sqliteConn(ctx, r.db).QueryRowContext(ctx, query, id), user)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *sqliteUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + sqliteUserColumns + ` FROM users ORDER BY id`
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := scanSQLiteUser(rows, &u); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (r *sqliteUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE users SET password = ? WHERE id = ?`
+	_, err := sqliteConn(ctx, r.db).ExecContext(ctx, query, passwordHash, id)
+	return err
+}
+
+func (r *sqliteUserRepository) MarkEmailVerified(ctx context.Context, id int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE users SET email_verified = TRUE WHERE id = ?`
+	_, err := sqliteConn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *sqliteUserRepository) Update(ctx context.Context, user *domain.User) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE users SET name = ?, email = ?, email_verified = ? WHERE id = ?`
+	_, err := sqliteConn(ctx, r.db).ExecContext(ctx, query, user.Name, user.Email, user.EmailVerified, user.ID)
+	if isSQLiteUniqueViolation(err, "users.email") {
+		return domain.ErrEmailTaken
+	}
+	return err
+}
+
+// Delete removes the user; their videos go with them through ON DELETE CASCADE
+func (r *sqliteUserRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Personal videos go with the account, while organization videos are kept and their
+	// user_id is set to NULL by the foreign key
+	return NewSQLiteUnitOfWork(r.db).Do(ctx, func(ctx context.Context) error {
+		if _, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM videos WHERE user_id = ? AND organization_id IS NULL`, id); err != nil {
+			return err
+		}
+		_, err := sqliteConn(ctx, r.db).ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+		return err
+	})
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+type sqliteVideoRepository struct {
+	db      *sql.DB
+	orgs    ports.OrganizationRepository
+	timeout time.Duration
+}
+
+// NewSQLiteVideoRepository bounds every query with timeout. Organizations are not stored in
+// SQLite, so the memberships that grant access to organization videos come from orgs.
+func NewSQLiteVideoRepository(db *sql.DB, orgs ports.OrganizationRepository, timeout time.Duration) ports.VideoRepository {
+	return &sqliteVideoRepository{
+		db:      db,
+		orgs:    orgs,
+		timeout: timeout,
+	}
+}
+
+func (r *sqliteVideoRepository) Create(ctx context.Context, video *domain.Video) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := sqliteNow()
+	query := `
+		INSERT INTO videos (user_id, organization_id, original_filename, storage_key, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id, created_at, updated_at
+	`
+	err := sqliteConn(ctx, r.db).QueryRowContext(ctx, query, video.UserID, video.OrganizationID, video.OriginalFilename, video.StorageKey, video.Status, now, now).
+		Scan(&video.ID, &video.CreatedAt, &video.UpdatedAt)
+	if isSQLiteUniqueViolation(err, "videos.storage_key") {
+		return domain.ErrStorageKeyTaken
+	}
+	return err
+}
+
+func (r *sqliteVideoRepository) Update(ctx context.Context, video *domain.Video) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	record := newVideoRecord(video)
+	query := `
+		UPDATE videos
+		SET status = ?, zip_path = ?, frame_count = ?, message = ?, error_code = ?, worker_id = ?, output_size = ?,
+			processing_started_at = ?, processing_finished_at = ?, updated_at = ?
+		WHERE id = ?
+		RETURNING updated_at
+	`
+	err := sqliteConn(ctx, r.db).QueryRowContext(ctx, query, record.Status, record.ZipPath, record.FrameCount, record.Message, record.ErrorCode, record.WorkerID, record.OutputSize,
+		record.ProcessingStartedAt, record.ProcessingFinishedAt, sqliteNow(), record.ID).
+		Scan(&video.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrVideoNotFound
+	}
+	return err
+}
+
+func (r *sqliteVideoRepository) GetByID(ctx context.Context, id int64) (*domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + videoColumns + ` FROM videos WHERE id = ?`
+	var record videoRecord
+	err := record.scan(sqliteConn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	video := record.toDomain()
+	return &video, nil
+}
+
+func (r *sqliteVideoRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + videoColumns + ` FROM videos WHERE user_id = ? ORDER BY created_at DESC, id DESC`
+	return r.queryVideos(ctx, query, userID)
+}
+
+func (r *sqliteVideoRepository) ListAccessibleByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	orgs, err := r.orgs.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{userID}
+	placeholders := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		args = append(args, org.ID)
+		placeholders = append(placeholders, "?")
+	}
+
+	query := `SELECT ` + videoColumns + ` FROM videos WHERE (organization_id IS NULL AND user_id = ?)`
+	if len(placeholders) > 0 {
+		query += ` OR organization_id IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	query += ` ORDER BY created_at DESC, id DESC`
+	return r.queryVideos(ctx, query, args...)
+}
+
+func (r *sqliteVideoRepository) List(ctx context.Context) ([]domain.Video, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `SELECT ` + videoColumns + ` FROM videos ORDER BY created_at DESC, id DESC`
+	return r.queryVideos(ctx, query)
+}
+
+func (r *sqliteVideoRepository) queryVideos(ctx context.Context, query string, args ...any) ([]domain.Video, error) {
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanVideos(rows)
+}
+
+func (r *sqliteVideoRepository) GetStatusHistoryByUserID(ctx context.Context, userID int64) ([]domain.VideoStatusEvent, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		SELECT h.video_id, h.status, COALESCE(h.message, ''), h.created_at
+		FROM video_status_history h
+		JOIN videos v ON v.id = h.video_id
+		WHERE v.user_id = ?
+		ORDER BY h.created_at, h.id
+	`
+	rows, err := sqliteConn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.VideoStatusEvent
+	for rows.Next() {
+		var e domain.VideoStatusEvent
+		if err := rows.Scan(&e.VideoID, &e.Status, &e.Message, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+	"video-processor/internal/core/domain"
+)
+
+// videoColumns matches the scan order of videoRecord.scan
+const videoColumns = `id, user_id, organization_id, original_filename, storage_key, status, zip_path, frame_count, message,
+	error_code, worker_id, output_size, processing_started_at, processing_finished_at, created_at, updated_at`
+
+// videoRecord is a row of the videos table as stored by Postgres and SQLite. Columns the
+// worker fills in only once it gets to the video are NULL until then; the domain model
+// reads them as zero values.
+type videoRecord struct {
+	ID                   int64
+	UserID               sql.NullInt64 // NULL once the uploader of an organization video is deleted
+	OrganizationID       sql.NullInt64
+	OriginalFilename     string
+	StorageKey           string
+	Status               string
+	ZipPath              sql.NullString
+	FrameCount           sql.NullInt64
+	Message              sql.NullString
+	ErrorCode            sql.NullString
+	WorkerID             sql.NullString
+	OutputSize           sql.NullInt64
+	ProcessingStartedAt  sql.NullTime
+	ProcessingFinishedAt sql.NullTime
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// newVideoRecord maps the zero values of video back to NULL
+func newVideoRecord(video *domain.Video) videoRecord {
+	record := videoRecord{
+		ID:                   video.ID,
+		UserID:               sql.NullInt64{Int64: video.UserID, Valid: video.UserID != 0},
+		OriginalFilename:     video.OriginalFilename,
+		StorageKey:           video.StorageKey,
+		Status:               video.Status,
+		ZipPath:              nullString(video.ZipPath),
+		FrameCount:           sql.NullInt64{Int64: int64(video.FrameCount), Valid: true},
+		Message:              nullString(video.Message),
+		ErrorCode:            nullString(video.ErrorCode),
+		WorkerID:             nullString(video.WorkerID),
+		OutputSize:           sql.NullInt64{Int64: video.OutputSize, Valid: video.OutputSize != 0},
+		ProcessingStartedAt:  nullTime(video.ProcessingStartedAt),
+		ProcessingFinishedAt: nullTime(video.ProcessingFinishedAt),
+		CreatedAt:            video.CreatedAt,
+		UpdatedAt:            video.UpdatedAt,
+	}
+	if video.OrganizationID != nil {
+		record.OrganizationID = sql.NullInt64{Int64: *video.OrganizationID, Valid: true}
+	}
+	return record
+}
+
+func (r *videoRecord) scan(row interface{ Scan(...any) error }) error {
+	return row.Scan(&r.ID, &r.UserID, &r.OrganizationID, &r.OriginalFilename, &r.StorageKey, &r.Status, &r.ZipPath, &r.FrameCount, &r.Message,
+		&r.ErrorCode, &r.WorkerID, &r.OutputSize, &r.ProcessingStartedAt, &r.ProcessingFinishedAt, &r.CreatedAt, &r.UpdatedAt)
+}
+
+func (r videoRecord) toDomain() domain.Video {
+	video := domain.Video{
+		ID:                   r.ID,
+		UserID:               r.UserID.Int64,
+		OriginalFilename:     r.OriginalFilename,
+		StorageKey:           r.StorageKey,
+		Status:               r.Status,
+		ZipPath:              r.ZipPath.String,
+		FrameCount:           int(r.FrameCount.Int64),
+		Message:              r.Message.String,
+		ErrorCode:            r.ErrorCode.String,
+		WorkerID:             r.WorkerID.String,
+		OutputSize:           r.OutputSize.Int64,
+		ProcessingStartedAt:  timePtr(r.ProcessingStartedAt),
+		ProcessingFinishedAt: timePtr(r.ProcessingFinishedAt),
+		CreatedAt:            r.CreatedAt,
+		UpdatedAt:            r.UpdatedAt,
+	}
+	if r.OrganizationID.Valid {
+		video.OrganizationID = &r.OrganizationID.Int64
+	}
+	return video
+}
+
+// scanVideos reads every row of rows into the domain model
+func scanVideos(rows interface {
+	Next() bool
+	Scan(...any) error
+	Err() error
+}) ([]domain.Video, error) {
+	var videos []domain.Video
+	for rows.Next() {
+		var record videoRecord
+		if err := record.scan(rows); err != nil {
+			return nil, err
+		}
+		videos = append(videos, record.toDomain())
+	}
+	return videos, rows.Err()
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
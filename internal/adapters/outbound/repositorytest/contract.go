@@ -0,0 +1,472 @@
+// Package repositorytest is the contract every UserRepository and VideoRepository adapter
+// must satisfy, so Postgres, SQLite and the in-memory adapters behave the same.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories are the adapters under test, backed by the same empty database
+type Repositories struct {
+	Users  ports.UserRepository
+	Videos ports.VideoRepository
+	// Organizations grants the memberships seen by Videos.ListAccessibleByUserID
+	Organizations ports.OrganizationRepository
+	UnitOfWork    ports.UnitOfWork
+}
+
+// Setup returns the repositories for one test, starting from an empty database
+type Setup func(t *testing.T) Repositories
+
+// Run checks every repository method against setup
+func Run(t *testing.T, setup Setup) {
+	t.Run("UserRepository", func(t *testing.T) { testUserRepository(t, setup) })
+	t.Run("VideoRepository", func(t *testing.T) { testVideoRepository(t, setup) })
+	t.Run("UnitOfWork", func(t *testing.T) { testUnitOfWork(t, setup) })
+}
+
+func newUser(email string) *domain.User {
+	return &domain.User{Email: email, Password: "hash", Name: "Name", Role: domain.RoleUser}
+}
+
+func createUser(t *testing.T, repos Repositories, email string) *domain.User {
+	t.Helper()
+	user := newUser(email)
+	require.NoError(t, repos.Users.Create(context.Background(), user))
+	return user
+}
+
+func createVideo(t *testing.T, repos Repositories, userID int64, storageKey string, organizationID *int64) *domain.Video {
+	t.Helper()
+	video := &domain.Video{UserID: userID, OrganizationID: organizationID, OriginalFilename: "clip.mp4", StorageKey: storageKey, Status: domain.StatusPending}
+	require.NoError(t, repos.Videos.Create(context.Background(), video))
+	return video
+}
+
+func videoIDs(videos []domain.Video) []int64 {
+	var ids []int64
+	for _, v := range videos {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func testUserRepository(t *testing.T, setup Setup) {
+	ctx := context.Background()
+
+	t.Run("create and get", func(t *testing.T) {
+		repos := setup(t)
+		user := newUser("user@example.com")
+		user.EmailVerified = true
+
+		require.NoError(t, repos.Users.Create(ctx, user))
+		assert.NotZero(t, user.ID)
+		assert.WithinDuration(t, time.Now(), user.CreatedAt, time.Minute)
+
+		byID, err := repos.Users.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, byID)
+		assert.Equal(t, user.Email, byID.Email)
+		assert.Equal(t, "hash", byID.Password)
+		assert.Equal(t, "Name", byID.Name)
+		assert.Equal(t, domain.RoleUser, byID.Role)
+		assert.True(t, byID.EmailVerified)
+		assert.True(t, user.CreatedAt.Equal(byID.CreatedAt))
+
+		byEmail, err := repos.Users.GetByEmail(ctx, user.Email)
+		require.NoError(t, err)
+		require.NotNil(t, byEmail)
+		assert.Equal(t, user.ID, byEmail.ID)
+	})
+
+	t.Run("unknown user is nil", func(t *testing.T) {
+		repos := setup(t)
+
+		byID, err := repos.Users.GetByID(ctx, 404)
+		assert.NoError(t, err)
+		assert.Nil(t, byID)
+
+		byEmail, err := repos.Users.GetByEmail(ctx, "nobody@example.com")
+		assert.NoError(t, err)
+		assert.Nil(t, byEmail)
+	})
+
+	t.Run("emails are unique", func(t *testing.T) {
+		repos := setup(t)
+		createUser(t, repos, "taken@example.com")
+		other := createUser(t, repos, "other@example.com")
+
+		err := repos.Users.Create(ctx, newUser("taken@example.com"))
+		assert.ErrorIs(t, err, domain.ErrEmailTaken)
+
+		other.Email = "taken@example.com"
+		err = repos.Users.Update(ctx, other)
+		assert.ErrorIs(t, err, domain.ErrEmailTaken)
+
+		stored, _ := repos.Users.GetByID(ctx, other.ID)
+		assert.Equal(t, "other@example.com", stored.Email)
+	})
+
+	t.Run("list orders by id", func(t *testing.T) {
+		repos := setup(t)
+		first := createUser(t, repos, "b@example.com")
+		second := createUser(t, repos, "a@example.com")
+
+		users, err := repos.Users.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, first.ID, users[0].ID)
+		assert.Equal(t, second.ID, users[1].ID)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		repos := setup(t)
+
+		users, err := repos.Users.List(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("update saves name, email and verification only", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "old@example.com")
+
+		user.Name = "New Name"
+		user.Email = "new@example.com"
+		user.EmailVerified = true
+		user.Password = "ignored"
+		user.Role = domain.RoleAdmin
+		require.NoError(t, repos.Users.Update(ctx, user))
+
+		stored, err := repos.Users.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "New Name", stored.Name)
+		assert.Equal(t, "new@example.com", stored.Email)
+		assert.True(t, stored.EmailVerified)
+		assert.Equal(t, "hash", stored.Password)
+		assert.Equal(t, domain.RoleUser, stored.Role)
+	})
+
+	t.Run("password and verification", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+
+		require.NoError(t, repos.Users.UpdatePassword(ctx, user.ID, "new-hash"))
+		require.NoError(t, repos.Users.MarkEmailVerified(ctx, user.ID))
+
+		stored, err := repos.Users.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new-hash", stored.Password)
+		assert.True(t, stored.EmailVerified)
+	})
+
+	t.Run("updating an unknown user is not an error", func(t *testing.T) {
+		repos := setup(t)
+
+		assert.NoError(t, repos.Users.Update(ctx, &domain.User{ID: 404, Email: "nobody@example.com"}))
+		assert.NoError(t, repos.Users.UpdatePassword(ctx, 404, "hash"))
+		assert.NoError(t, repos.Users.MarkEmailVerified(ctx, 404))
+	})
+
+	t.Run("delete cascades to personal videos", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+		other := createUser(t, repos, "other@example.com")
+		team := &domain.Organization{Name: "Team"}
+		require.NoError(t, repos.Organizations.Create(ctx, team, user.ID))
+		video := createVideo(t, repos, user.ID, "a.mp4", nil)
+		kept := createVideo(t, repos, other.ID, "b.mp4", nil)
+		shared := createVideo(t, repos, user.ID, "c.mp4", &team.ID)
+
+		require.NoError(t, repos.Users.Delete(ctx, user.ID))
+
+		stored, err := repos.Users.GetByID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, stored)
+		gone, err := repos.Videos.GetByID(ctx, video.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, gone)
+		remaining, err := repos.Videos.GetByID(ctx, kept.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, remaining)
+
+		// The organization keeps the video, no longer tied to its uploader
+		orphan, err := repos.Videos.GetByID(ctx, shared.ID)
+		require.NoError(t, err)
+		require.NotNil(t, orphan)
+		assert.Zero(t, orphan.UserID)
+		require.NotNil(t, orphan.OrganizationID)
+		assert.Equal(t, team.ID, *orphan.OrganizationID)
+		own, err := repos.Videos.GetByUserID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, own)
+
+		assert.NoError(t, repos.Users.Delete(ctx, user.ID))
+	})
+}
+
+func testVideoRepository(t *testing.T, setup Setup) {
+	ctx := context.Background()
+
+	t.Run("create and get with empty optional columns", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+		video := createVideo(t, repos, user.ID, "a.mp4", nil)
+
+		assert.NotZero(t, video.ID)
+		assert.WithinDuration(t, time.Now(), video.CreatedAt, time.Minute)
+		assert.True(t, video.CreatedAt.Equal(video.UpdatedAt))
+
+		stored, err := repos.Videos.GetByID(ctx, video.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, user.ID, stored.UserID)
+		assert.Nil(t, stored.OrganizationID)
+		assert.Equal(t, "clip.mp4", stored.OriginalFilename)
+		assert.Equal(t, "a.mp4", stored.StorageKey)
+		assert.Equal(t, domain.StatusPending, stored.Status)
+		assert.Empty(t, stored.ZipPath)
+		assert.Zero(t, stored.FrameCount)
+		assert.Empty(t, stored.Message)
+		assert.Empty(t, stored.ErrorCode)
+		assert.Empty(t, stored.WorkerID)
+		assert.Zero(t, stored.OutputSize)
+		assert.Nil(t, stored.ProcessingStartedAt)
+		assert.Nil(t, stored.ProcessingFinishedAt)
+		assert.True(t, video.CreatedAt.Equal(stored.CreatedAt))
+	})
+
+	t.Run("unknown video is nil", func(t *testing.T) {
+		repos := setup(t)
+
+		stored, err := repos.Videos.GetByID(ctx, 404)
+		assert.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("storage keys are unique", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+		createVideo(t, repos, user.ID, "a.mp4", nil)
+
+		err := repos.Videos.Create(ctx, &domain.Video{UserID: user.ID, OriginalFilename: "clip.mp4", StorageKey: "a.mp4", Status: domain.StatusPending})
+		assert.ErrorIs(t, err, domain.ErrStorageKeyTaken)
+	})
+
+	t.Run("update records status history", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+		video := createVideo(t, repos, user.ID, "a.mp4", nil)
+		createdAt := video.UpdatedAt
+
+		video.Status = domain.StatusProcessing
+		require.NoError(t, repos.Videos.Update(ctx, video))
+		video.Status = domain.StatusCompleted
+		video.ZipPath = "/app/outputs/a.zip"
+		video.FrameCount = 42
+		video.Message = "done"
+		require.NoError(t, repos.Videos.Update(ctx, video))
+		// Saving the same status again is not a new history entry
+		require.NoError(t, repos.Videos.Update(ctx, video))
+
+		stored, err := repos.Videos.GetByID(ctx, video.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusCompleted, stored.Status)
+		assert.Equal(t, "/app/outputs/a.zip", stored.ZipPath)
+		assert.Equal(t, 42, stored.FrameCount)
+		assert.Equal(t, "done", stored.Message)
+		assert.False(t, stored.UpdatedAt.Before(createdAt))
+		assert.True(t, video.UpdatedAt.Equal(stored.UpdatedAt))
+
+		history, err := repos.Videos.GetStatusHistoryByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		var statuses []string
+		for _, e := range history {
+			assert.Equal(t, video.ID, e.VideoID)
+			statuses = append(statuses, e.Status)
+		}
+		assert.Equal(t, []string{domain.StatusPending, domain.StatusProcessing, domain.StatusCompleted}, statuses)
+		assert.Equal(t, "done", history[2].Message)
+	})
+
+	t.Run("update saves processing details", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+		video := createVideo(t, repos, user.ID, "a.mp4", nil)
+
+		startedAt := time.Now().Add(-time.Minute).Truncate(time.Microsecond)
+		finishedAt := startedAt.Add(30 * time.Second)
+		video.Status = domain.StatusFailed
+		video.Message = "ffmpeg failed"
+		video.ErrorCode = "ERR_FFMPEG"
+		video.WorkerID = "worker-1"
+		video.OutputSize = 1 << 32
+		video.ProcessingStartedAt = &startedAt
+		video.ProcessingFinishedAt = &finishedAt
+		require.NoError(t, repos.Videos.Update(ctx, video))
+
+		stored, err := repos.Videos.GetByID(ctx, video.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ERR_FFMPEG", stored.ErrorCode)
+		assert.Equal(t, "worker-1", stored.WorkerID)
+		assert.Equal(t, int64(1<<32), stored.OutputSize)
+		require.NotNil(t, stored.ProcessingStartedAt)
+		require.NotNil(t, stored.ProcessingFinishedAt)
+		assert.True(t, startedAt.Equal(*stored.ProcessingStartedAt))
+		assert.True(t, finishedAt.Equal(*stored.ProcessingFinishedAt))
+
+		// Clearing the details stores NULLs that read back as zero values
+		video.ErrorCode, video.WorkerID, video.OutputSize = "", "", 0
+		video.ProcessingStartedAt, video.ProcessingFinishedAt = nil, nil
+		require.NoError(t, repos.Videos.Update(ctx, video))
+
+		videos, err := repos.Videos.GetByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, videos, 1)
+		assert.Empty(t, videos[0].ErrorCode)
+		assert.Empty(t, videos[0].WorkerID)
+		assert.Zero(t, videos[0].OutputSize)
+		assert.Nil(t, videos[0].ProcessingStartedAt)
+		assert.Nil(t, videos[0].ProcessingFinishedAt)
+	})
+
+	t.Run("updating an unknown video", func(t *testing.T) {
+		repos := setup(t)
+
+		err := repos.Videos.Update(ctx, &domain.Video{ID: 404, Status: domain.StatusFailed})
+		assert.ErrorIs(t, err, domain.ErrVideoNotFound)
+	})
+
+	t.Run("lists are newest first", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+		other := createUser(t, repos, "other@example.com")
+		first := createVideo(t, repos, user.ID, "a.mp4", nil)
+		second := createVideo(t, repos, other.ID, "b.mp4", nil)
+		third := createVideo(t, repos, user.ID, "c.mp4", nil)
+
+		own, err := repos.Videos.GetByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{third.ID, first.ID}, videoIDs(own))
+
+		all, err := repos.Videos.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{third.ID, second.ID, first.ID}, videoIDs(all))
+
+		none, err := repos.Videos.GetByUserID(ctx, 404)
+		assert.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
+	t.Run("accessible videos include organization videos", func(t *testing.T) {
+		repos := setup(t)
+		user := createUser(t, repos, "user@example.com")
+		teammate := createUser(t, repos, "teammate@example.com")
+		outsider := createUser(t, repos, "outsider@example.com")
+		team := &domain.Organization{Name: "Team"}
+		require.NoError(t, repos.Organizations.Create(ctx, team, teammate.ID))
+		require.NoError(t, repos.Organizations.AddMember(ctx, &domain.OrganizationMember{OrganizationID: team.ID, UserID: user.ID, Role: domain.OrgRoleViewer}))
+		other := &domain.Organization{Name: "Other"}
+		require.NoError(t, repos.Organizations.Create(ctx, other, outsider.ID))
+
+		personal := createVideo(t, repos, user.ID, "a.mp4", nil)
+		shared := createVideo(t, repos, teammate.ID, "b.mp4", &team.ID)
+		createVideo(t, repos, teammate.ID, "c.mp4", nil)
+		createVideo(t, repos, outsider.ID, "d.mp4", &other.ID)
+		// Uploaded by the user, but to an organization they no longer see
+		createVideo(t, repos, user.ID, "e.mp4", &other.ID)
+
+		videos, err := repos.Videos.ListAccessibleByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{shared.ID, personal.ID}, videoIDs(videos))
+		require.NotNil(t, videos[0].OrganizationID)
+		assert.Equal(t, team.ID, *videos[0].OrganizationID)
+	})
+}
+
+func testUnitOfWork(t *testing.T, setup Setup) {
+	ctx := context.Background()
+
+	t.Run("commit keeps the changes", func(t *testing.T) {
+		repos := setup(t)
+
+		var user *domain.User
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			user = newUser("user@example.com")
+			return repos.Users.Create(ctx, user)
+		})
+		require.NoError(t, err)
+
+		stored, err := repos.Users.GetByID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, stored)
+	})
+
+	t.Run("failure rolls back every change", func(t *testing.T) {
+		repos := setup(t)
+		existing := createUser(t, repos, "existing@example.com")
+		video := createVideo(t, repos, existing.ID, "a.mp4", nil)
+		failure := errors.New("storage failed")
+
+		var created *domain.User
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			created = newUser("new@example.com")
+			if err := repos.Users.Create(ctx, created); err != nil {
+				return err
+			}
+			video.Status = domain.StatusFailed
+			if err := repos.Videos.Update(ctx, video); err != nil {
+				return err
+			}
+			if err := repos.Users.Delete(ctx, existing.ID); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		gone, err := repos.Users.GetByEmail(ctx, "new@example.com")
+		assert.NoError(t, err)
+		assert.Nil(t, gone)
+		kept, err := repos.Users.GetByID(ctx, existing.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, kept)
+		stored, err := repos.Videos.GetByID(ctx, video.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, domain.StatusPending, stored.Status)
+	})
+
+	t.Run("nested failure only undoes its own changes", func(t *testing.T) {
+		repos := setup(t)
+		failure := errors.New("nested failed")
+
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			if err := repos.Users.Create(ctx, newUser("outer@example.com")); err != nil {
+				return err
+			}
+			nestedErr := repos.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+				if err := repos.Users.Create(ctx, newUser("inner@example.com")); err != nil {
+					return err
+				}
+				return failure
+			})
+			assert.ErrorIs(t, nestedErr, failure)
+			return nil
+		})
+		require.NoError(t, err)
+
+		outer, _ := repos.Users.GetByEmail(ctx, "outer@example.com")
+		assert.NotNil(t, outer)
+		inner, _ := repos.Users.GetByEmail(ctx, "inner@example.com")
+		assert.Nil(t, inner)
+	})
+}
@@ -0,0 +1,48 @@
+package signing
+
+import (
+	"errors"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultDevSecret is only accepted in development mode
+const DefaultDevSecret = "fiapx-secret-key"
+
+type hmacSigner struct {
+	secret []byte
+}
+
+// NewHMACSigner signs tokens with a shared HS256 secret. Every verifier needs the secret,
+// so it is meant for development and legacy deployments only.
+func NewHMACSigner(secret string) ports.TokenSigner {
+	return &hmacSigner{
+		secret: []byte(secret),
+	}
+}
+
+func (s *hmacSigner) Sign(claims map[string]interface{}) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(claims))
+	return token.SignedString(s.secret)
+}
+
+func (s *hmacSigner) Verify(tokenString string) (map[string]interface{}, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token claims")
+	}
+	return claims, nil
+}
+
+func (s *hmacSigner) PublicKeys() domain.JWKS {
+	return domain.JWKS{Keys: []domain.JWK{}}
+}
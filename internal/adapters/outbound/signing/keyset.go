@@ -0,0 +1,360 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmHS256 = "HS256"
+
+	rsaKeyBits = 2048
+	// rotationCheckPeriod is how often the key directory is re-read and the active key's age checked
+	rotationCheckPeriod = time.Minute
+	// minReloadInterval throttles directory reloads triggered by tokens signed with an unknown kid
+	minReloadInterval = 10 * time.Second
+)
+
+type KeySetOptions struct {
+	Algorithm        string        // RS256 or EdDSA
+	Dir              string        // Optional directory where keys are persisted, shareable between instances
+	RotationInterval time.Duration // How long a key signs new tokens before a new one takes over
+	Retention        time.Duration // How long a retired key is still published and accepted for verification
+}
+
+type signingKey struct {
+	kid       string
+	private   crypto.Signer
+	createdAt time.Time
+}
+
+// KeySet signs tokens with its newest key and verifies them with any published key,
+// selected by the "kid" header. Rotating adds a new signing key while previous keys keep
+// verifying tokens issued before the rotation until their retention expires.
+type KeySet struct {
+	opts       KeySetOptions
+	method     jwt.SigningMethod
+	mu         sync.RWMutex
+	keys       []*signingKey // Newest first
+	lastReload time.Time
+}
+
+func NewKeySet(opts KeySetOptions) (*KeySet, error) {
+	var method jwt.SigningMethod
+	switch opts.Algorithm {
+	case AlgorithmRS256:
+		method = jwt.SigningMethodRS256
+	case AlgorithmEdDSA:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", opts.Algorithm)
+	}
+	if opts.RotationInterval <= 0 {
+		return nil, errors.New("key rotation interval must be positive")
+	}
+
+	ks := &KeySet{
+		opts:   opts,
+		method: method,
+	}
+
+	if opts.Dir != "" {
+		if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+			return nil, fmt.Errorf("error creating key directory: %w", err)
+		}
+		if err := ks.reload(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ks.rotateIfDue(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func (ks *KeySet) Sign(claims map[string]interface{}) (string, error) {
+	ks.mu.RLock()
+	active := ks.keys[0]
+	ks.mu.RUnlock()
+
+	token := jwt.NewWithClaims(ks.method, jwt.MapClaims(claims))
+	token.Header["kid"] = active.kid
+	return token.SignedString(active.private)
+}
+
+func (ks *KeySet) Verify(tokenString string) (map[string]interface{}, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key := ks.find(kid)
+		if key == nil {
+			// Another instance sharing the key directory may have rotated
+			ks.reloadThrottled()
+			key = ks.find(kid)
+		}
+		if key == nil {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key.private.Public(), nil
+	}, jwt.WithValidMethods([]string{ks.method.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token claims")
+	}
+	return claims, nil
+}
+
+func (ks *KeySet) PublicKeys() domain.JWKS {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	jwks := domain.JWKS{Keys: make([]domain.JWK, 0, len(ks.keys))}
+	for _, key := range ks.keys {
+		jwk := publicJWK(key.private.Public())
+		jwk.Kid = key.kid
+		jwk.Alg = ks.method.Alg()
+		jwk.Use = "sig"
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// Rotate generates a new signing key and retires keys past their retention
+func (ks *KeySet) Rotate() error {
+	private, err := ks.generate()
+	if err != nil {
+		return fmt.Errorf("error generating signing key: %w", err)
+	}
+
+	kid, err := thumbprint(private.Public())
+	if err != nil {
+		return err
+	}
+	key := &signingKey{kid: kid, private: private, createdAt: time.Now()}
+
+	if ks.opts.Dir != "" {
+		if err := ks.persist(key); err != nil {
+			return err
+		}
+	}
+
+	ks.mu.Lock()
+	ks.keys = append([]*signingKey{key}, ks.keys...)
+	ks.mu.Unlock()
+
+	ks.prune()
+	log.Printf("🔑 Chave de assinatura JWT rotacionada: kid=%s, alg=%s", kid, ks.method.Alg())
+	return nil
+}
+
+// StartRotation rotates the signing key whenever the active one reaches the rotation
+// interval, until the context is cancelled.
+func (ks *KeySet) StartRotation(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(rotationCheckPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if ks.opts.Dir != "" {
+					if err := ks.reload(); err != nil {
+						log.Printf("⚠️ Erro ao recarregar as chaves de assinatura JWT: %v", err)
+					}
+				}
+				if err := ks.rotateIfDue(); err != nil {
+					log.Printf("❌ Erro ao rotacionar a chave de assinatura JWT: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (ks *KeySet) rotateIfDue() error {
+	ks.mu.RLock()
+	due := len(ks.keys) == 0 || time.Since(ks.keys[0].createdAt) >= ks.opts.RotationInterval
+	ks.mu.RUnlock()
+
+	if !due {
+		return nil
+	}
+	return ks.Rotate()
+}
+
+func (ks *KeySet) find(kid string) *signingKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	for _, key := range ks.keys {
+		if key.kid == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+// prune drops keys that stopped signing more than Retention ago. The newest key is always kept.
+func (ks *KeySet) prune() {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	kept := ks.keys[:1]
+	for i := 1; i < len(ks.keys); i++ {
+		// A key stopped signing when the next newer key was created
+		retiredAt := ks.keys[i-1].createdAt
+		if time.Since(retiredAt) < ks.opts.Retention {
+			kept = append(kept, ks.keys[i])
+			continue
+		}
+		if ks.opts.Dir != "" {
+			os.Remove(filepath.Join(ks.opts.Dir, ks.keys[i].kid+".pem"))
+		}
+	}
+	ks.keys = kept
+}
+
+func (ks *KeySet) generate() (crypto.Signer, error) {
+	if ks.opts.Algorithm == AlgorithmRS256 {
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	}
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	return private, err
+}
+
+func (ks *KeySet) persist(key *signingKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.private)
+	if err != nil {
+		return fmt.Errorf("error encoding signing key: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(ks.opts.Dir, ".key-*")
+	if err != nil {
+		return fmt.Errorf("error writing signing key: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := pem.Encode(tmp, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing signing key: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing signing key: %w", err)
+	}
+	return os.Rename(tmp.Name(), filepath.Join(ks.opts.Dir, key.kid+".pem"))
+}
+
+// reload replaces the in-memory keys with the ones found in the key directory
+func (ks *KeySet) reload() error {
+	paths, err := filepath.Glob(filepath.Join(ks.opts.Dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	var keys []*signingKey
+	for _, path := range paths {
+		key, err := ks.load(path)
+		if err != nil {
+			log.Printf("⚠️ Ignorando a chave de assinatura JWT %s: %v", path, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].createdAt.After(keys[j].createdAt)
+	})
+
+	ks.mu.Lock()
+	ks.lastReload = time.Now()
+	if len(keys) > 0 {
+		ks.keys = keys
+	}
+	ks.mu.Unlock()
+	return nil
+}
+
+func (ks *KeySet) reloadThrottled() {
+	if ks.opts.Dir == "" {
+		return
+	}
+	ks.mu.RLock()
+	recent := time.Since(ks.lastReload) < minReloadInterval
+	ks.mu.RUnlock()
+
+	if !recent {
+		if err := ks.reload(); err != nil {
+			log.Printf("⚠️ Erro ao recarregar as chaves de assinatura JWT: %v", err)
+		}
+	}
+}
+
+func (ks *KeySet) load(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("not a PKCS#8 PEM private key")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var private crypto.Signer
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if ks.opts.Algorithm == AlgorithmRS256 {
+			private = k
+		}
+	case ed25519.PrivateKey:
+		if ks.opts.Algorithm == AlgorithmEdDSA {
+			private = k
+		}
+	}
+	if private == nil {
+		return nil, fmt.Errorf("key type does not match algorithm %s", ks.opts.Algorithm)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := thumbprint(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	if name := strings.TrimSuffix(filepath.Base(path), ".pem"); name != kid {
+		return nil, fmt.Errorf("file name does not match key id %s", kid)
+	}
+
+	return &signingKey{kid: kid, private: private, createdAt: info.ModTime()}, nil
+}
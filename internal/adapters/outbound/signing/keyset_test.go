@@ -0,0 +1,104 @@
+package signing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeySet(t *testing.T, dir string) *KeySet {
+	t.Helper()
+	ks, err := NewKeySet(KeySetOptions{Algorithm: AlgorithmEdDSA, Dir: dir, RotationInterval: time.Hour, Retention: time.Hour})
+	require.NoError(t, err)
+	return ks
+}
+
+func signTestToken(t *testing.T, ks *KeySet) string {
+	t.Helper()
+	token, err := ks.Sign(map[string]interface{}{"sub": "1", "exp": time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	return token
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	t.Run("rotated-out key verifies within retention", func(t *testing.T) {
+		ks := newTestKeySet(t, "")
+		token := signTestToken(t, ks)
+
+		require.NoError(t, ks.Rotate())
+
+		claims, err := ks.Verify(token)
+		require.NoError(t, err)
+		assert.Equal(t, "1", claims["sub"])
+		assert.Len(t, ks.PublicKeys().Keys, 2)
+	})
+
+	t.Run("rotated-out key is rejected after retention", func(t *testing.T) {
+		ks := newTestKeySet(t, "")
+		token := signTestToken(t, ks)
+		require.NoError(t, ks.Rotate())
+
+		// The key retired when its successor was created, longer ago than the retention
+		ks.keys[0].createdAt = time.Now().Add(-2 * time.Hour)
+		ks.prune()
+
+		_, err := ks.Verify(token)
+		assert.ErrorContains(t, err, "unknown key id")
+		assert.Len(t, ks.PublicKeys().Keys, 1)
+	})
+
+	t.Run("unknown kid is rejected", func(t *testing.T) {
+		ks := newTestKeySet(t, "")
+		other := newTestKeySet(t, "")
+
+		_, err := ks.Verify(signTestToken(t, other))
+
+		assert.ErrorContains(t, err, "unknown key id")
+	})
+}
+
+func TestKeySet_Dir(t *testing.T) {
+	t.Run("keys persisted by one instance are loaded by another", func(t *testing.T) {
+		dir := t.TempDir()
+		first := newTestKeySet(t, dir)
+		second := newTestKeySet(t, dir)
+
+		assert.Equal(t, first.PublicKeys(), second.PublicKeys())
+		_, err := second.Verify(signTestToken(t, first))
+		assert.NoError(t, err)
+	})
+
+	t.Run("a rotation by another instance is picked up on an unknown kid", func(t *testing.T) {
+		dir := t.TempDir()
+		first := newTestKeySet(t, dir)
+		second := newTestKeySet(t, dir)
+		require.NoError(t, first.Rotate())
+		token := signTestToken(t, first)
+
+		// Reloads are throttled, and second has just loaded the directory
+		_, err := second.Verify(token)
+		assert.ErrorContains(t, err, "unknown key id")
+
+		second.lastReload = time.Now().Add(-minReloadInterval)
+		_, err = second.Verify(token)
+		assert.NoError(t, err)
+		assert.Len(t, second.PublicKeys().Keys, 2)
+	})
+
+	t.Run("files that are not keys of the algorithm are ignored", func(t *testing.T) {
+		dir := t.TempDir()
+		rsaKeys, err := NewKeySet(KeySetOptions{Algorithm: AlgorithmRS256, Dir: dir, RotationInterval: time.Hour})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "garbage.pem"), []byte("not a key"), 0600))
+
+		ks := newTestKeySet(t, dir)
+
+		require.Len(t, ks.PublicKeys().Keys, 1)
+		assert.NotEqual(t, rsaKeys.PublicKeys().Keys[0].Kid, ks.PublicKeys().Keys[0].Kid)
+		assert.Equal(t, "OKP", ks.PublicKeys().Keys[0].Kty)
+	})
+}
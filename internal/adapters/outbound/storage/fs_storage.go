@@ -2,9 +2,13 @@ package storage
 
 import (
 	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 	"video-processor/internal/core/domain"
 	"video-processor/internal/core/ports"
 )
@@ -13,13 +17,17 @@ type fsStorage struct {
 	uploadDir string
 	outputDir string
 	tempDir   string
+	timeout   time.Duration
 }
 
-func NewFSStorage() ports.Storage {
+// NewFSStorage stores files under the given directories. timeout bounds each write,
+// including streaming an upload from the client.
+func NewFSStorage(uploadDir, outputDir, tempDir string, timeout time.Duration) ports.Storage {
 	storage := &fsStorage{
-		uploadDir: "/app/uploads",
-		outputDir: "/app/outputs",
-		tempDir:   "/app/temp",
+		uploadDir: uploadDir,
+		outputDir: outputDir,
+		tempDir:   tempDir,
+		timeout:   timeout,
 	}
 	storage.createDirs()
 	return storage
@@ -32,38 +40,106 @@ func (s *fsStorage) createDirs() {
 	}
 }
 
-func (s *fsStorage) SaveUpload(filename string, data io.Reader) (string, error) {
-	path := filepath.Join(s.uploadDir, filename)
-	out, err := os.Create(path)
-	if err != nil {
-		return "", err
+// CheckHealth writes and removes a probe file in every directory, catching full or
+// read-only volumes that a stat would not.
+func (s *fsStorage) CheckHealth(ctx context.Context) error {
+	for _, dir := range []string{s.uploadDir, s.outputDir, s.tempDir} {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		probe, err := os.CreateTemp(dir, ".healthcheck-*")
+		if err != nil {
+			return fmt.Errorf("%s is not writable: %w", dir, err)
+		}
+		_, err = probe.Write([]byte("ok"))
+		closeErr := probe.Close()
+		os.Remove(probe.Name())
+		if err != nil || closeErr != nil {
+			return fmt.Errorf("%s is not writable: %w", dir, errors.Join(err, closeErr))
+		}
 	}
-	defer out.Close()
+	return nil
+}
 
-	_, err = io.Copy(out, data)
-	return path, err
+func (s *fsStorage) SaveUpload(ctx context.Context, filename string, data io.Reader) (string, error) {
+	path := filepath.Join(s.uploadDir, filename)
+	return path, s.writeFile(ctx, path, data)
 }
 
-func (s *fsStorage) SaveZip(zipFilename string, files []string) error {
+func (s *fsStorage) SaveZip(ctx context.Context, zipFilename string, files []string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
 	zipPath := filepath.Join(s.outputDir, zipFilename)
+	if err := os.MkdirAll(filepath.Dir(zipPath), 0755); err != nil {
+		return err
+	}
 	zipFile, err := os.Create(zipPath)
 	if err != nil {
 		return err
 	}
-	defer zipFile.Close()
 
 	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
-
 	for _, file := range files {
-		if err := s.addFileToZip(zipWriter, file); err != nil {
-			return err
+		if err = s.addFileToZip(ctx, zipWriter, file); err != nil {
+			break
 		}
 	}
-	return nil
+	if closeErr := zipWriter.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := zipFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(zipPath)
+	}
+	return err
+}
+
+func (s *fsStorage) SaveTemp(ctx context.Context, filename string, data io.Reader) (string, error) {
+	path := filepath.Join(s.tempDir, filename)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	return path, s.writeFile(ctx, path, data)
 }
 
-func (s *fsStorage) addFileToZip(zipWriter *zip.Writer, filename string) error {
+// writeFile copies data to path, stopping when ctx is done. A partially written file is
+// removed, so a cancelled upload leaves nothing behind.
+func (s *fsStorage) writeFile(ctx context.Context, path string, data io.Reader) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, &contextReader{ctx: ctx, r: data})
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// contextReader fails reads once ctx is done, which is how file copies observe cancellation
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+func (s *fsStorage) addFileToZip(ctx context.Context, zipWriter *zip.Writer, filename string) error {
 	file, err := os.Open(filename)
 	if err != nil {
 		return err
@@ -88,19 +164,32 @@ func (s *fsStorage) addFileToZip(zipWriter *zip.Writer, filename string) error {
 		return err
 	}
 
-	_, err = io.Copy(writer, file)
+	_, err = io.Copy(writer, &contextReader{ctx: ctx, r: file})
 	return err
 }
 
-func (s *fsStorage) DeleteFile(path string) error {
-	return os.Remove(path)
+// DeleteFile removes a file; a file that is already gone is not an error
+func (s *fsStorage) DeleteFile(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
-func (s *fsStorage) DeleteDir(path string) error {
+func (s *fsStorage) DeleteDir(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return os.RemoveAll(path)
 }
 
-func (s *fsStorage) ListOutputs() ([]domain.FileInfo, error) {
+func (s *fsStorage) ListOutputs(ctx context.Context) ([]domain.FileInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	files, err := filepath.Glob(filepath.Join(s.outputDir, "*.zip"))
 	if err != nil {
 		return nil, err
@@ -117,7 +206,7 @@ func (s *fsStorage) ListOutputs() ([]domain.FileInfo, error) {
 			Name:        filepath.Base(file),
 			Size:        info.Size(),
 			CreatedAt:   info.ModTime().Format("2006-01-02 15:04:05"),
-			DownloadURL: "/download/" + filepath.Base(file),
+			DownloadURL: "/api/admin/files/" + filepath.Base(file),
 		})
 	}
 	return results, nil
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+)
+
+var (
+	ErrInvalidAccountToken = errors.New("link inválido, expirado ou já utilizado")
+	ErrEmailNotVerified    = errors.New("confirme seu e-mail antes de continuar")
+)
+
+// AccountToken is a single-use, time-limited token sent by email to prove ownership of
+// the address. Only its hash is stored.
+type AccountToken struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	Purpose   string     `json:"purpose"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Email is an outgoing plain-text message
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type MessageResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
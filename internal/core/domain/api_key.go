@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// APIKeyMarker starts every API key, so they can be told apart from JWTs at a glance
+const APIKeyMarker = "fxk_"
+
+const (
+	ScopeVideosRead  = "videos:read"
+	ScopeVideosWrite = "videos:write"
+	ScopeAdmin       = "admin"
+)
+
+// DefaultAPIKeyScopes are granted when a key is created without explicit scopes
+var DefaultAPIKeyScopes = []string{ScopeVideosRead, ScopeVideosWrite}
+
+var (
+	ErrAPIKeyNotFound      = errors.New("chave de API não encontrada")
+	ErrInvalidAPIKey       = errors.New("chave de API inválida, expirada ou revogada")
+	ErrInvalidScope        = errors.New("escopo de chave de API inválido")
+	ErrInvalidAPIKeyName   = errors.New("o nome da chave é obrigatório")
+	ErrInvalidAPIKeyExpiry = errors.New("a data de expiração deve estar no futuro")
+)
+
+type APIKey struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // Public part of the key, used for lookup and shown in listings
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type CreateAPIKeyResponse struct {
+	Success bool   `json:"success"`
+	Key     string `json:"key"` // Plain-text key, only returned once at creation
+	APIKey  APIKey `json:"api_key"`
+}
+
+type ListAPIKeysResponse struct {
+	Success bool     `json:"success"`
+	Keys    []APIKey `json:"keys"`
+}
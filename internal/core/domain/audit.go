@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	AuditActionAccountLocked   = "auth.account_locked"
+	AuditActionIPLocked        = "auth.ip_locked"
+	AuditActionAccountUnlocked = "admin.account_unlocked"
+	AuditActionMFAEnabled      = "auth.mfa_enabled"
+	AuditActionMFADisabled     = "auth.mfa_disabled"
+	AuditActionAccountDeleted  = "user.account_deleted"
+
+	AuditActionRegister        = "auth.register"
+	AuditActionLogin           = "auth.login"
+	AuditActionMFAVerify       = "auth.mfa_verify"
+	AuditActionSSOLogin        = "auth.sso_login"
+	AuditActionRefresh         = "auth.refresh"
+	AuditActionLogout          = "auth.logout"
+	AuditActionPasswordReset   = "auth.password_reset"
+	AuditActionPasswordChanged = "user.password_changed"
+	AuditActionAPIKeyCreated   = "api_key.created"
+	AuditActionAPIKeyRevoked   = "api_key.revoked"
+	AuditActionMemberRemoved   = "organization.member_removed"
+	AuditActionVideoUpload     = "video.upload"
+	AuditActionVideoDownload   = "video.download"
+	AuditActionFileDownload    = "file.download"
+	AuditActionExportDownload  = "data_export.download"
+	AuditActionVideoFailed     = "admin.video_failed"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure" // The action was attempted and did not succeed
+	AuditOutcomeDenied  = "denied"  // The actor was not allowed to attempt it
+)
+
+// AuditEvent is an append-only record of a security-relevant action
+type AuditEvent struct {
+	ID        int64             `json:"id"`
+	Action    string            `json:"action"`
+	Outcome   string            `json:"outcome"`            // Defaults to success
+	ActorID   *int64            `json:"actor_id,omitempty"` // User who performed the action, if known
+	Target    string            `json:"target,omitempty"`   // What the action applied to, e.g. "user:42"
+	IP        string            `json:"ip,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	RequestID string            `json:"request_id,omitempty"` // Matches the X-Request-ID response header
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// RequestMetadata describes the request an operation runs for, so that services auditing it
+// record the same client details as the handlers
+type RequestMetadata struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+type requestMetadataKey struct{}
+
+func ContextWithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, metadata)
+}
+
+// RequestMetadataFromContext returns the metadata stored in ctx, empty outside a request
+func RequestMetadataFromContext(ctx context.Context) RequestMetadata {
+	metadata, _ := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return metadata
+}
+
+// AuditFilter selects audit events; zero values match everything
+type AuditFilter struct {
+	Action    string
+	Outcome   string
+	ActorID   *int64
+	Target    string
+	IP        string
+	RequestID string
+	From      *time.Time // Inclusive
+	To        *time.Time // Exclusive
+	Limit     int
+	Offset    int
+}
+
+type ListAuditEventsResponse struct {
+	Success bool         `json:"success"`
+	Events  []AuditEvent `json:"events"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ExportStatusPending = "PENDING"
+	ExportStatusReady   = "READY"
+	ExportStatusFailed  = "FAILED"
+)
+
+var ErrExportNotFound = errors.New("exportação não encontrada ou expirada")
+
+// DataExport is a user's request for a copy of their personal data (LGPD art. 18)
+type DataExport struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	Status      string     `json:"status"`
+	FileName    string     `json:"-"` // Archive location in the output storage
+	TokenHash   string     `json:"-"` // Hash of the download token sent by email
+	Error       string     `json:"error,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // Download deadline, set once ready
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// VideoStatusEvent is one entry of a video's status history
+type VideoStatusEvent struct {
+	VideoID   int64     `json:"video_id"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+
+	ReadinessReady        = "ready"
+	ReadinessNotReady     = "not_ready"
+	ReadinessShuttingDown = "shutting_down"
+)
+
+// DependencyHealth is the result of probing one external dependency
+type DependencyHealth struct {
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latency_ms" example:"1.8"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessReport tells the orchestrator whether the instance can take traffic
+type ReadinessReport struct {
+	Status string                      `json:"status" example:"ready"`
+	Checks map[string]DependencyHealth `json:"checks"`
+}
+
+func (r ReadinessReport) Ready() bool {
+	return r.Status == ReadinessReady
+}
+
+// LivenessResponse is returned while the process is up
+type LivenessResponse struct {
+	Status string `json:"status" example:"ok"`
+}
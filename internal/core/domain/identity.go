@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidLoginState       = errors.New("sessão de login inválida ou expirada, tente novamente")
+	ErrExternalEmailUnverified = errors.New("o provedor de identidade não confirmou o e-mail da conta")
+)
+
+// ExternalIdentity is the verified result of a single sign-on login at the corporate IdP
+type ExternalIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// UserIdentity links a local user to an account at an external identity provider
+type UserIdentity struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OIDCLoginState keeps what is needed to finish an authorization-code + PKCE flow.
+// It is looked up by the hash of the state parameter and can only be consumed once.
+type OIDCLoginState struct {
+	ID           int64
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
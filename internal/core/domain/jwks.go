@@ -0,0 +1,17 @@
+package domain
+
+// JWK is a public JSON Web Key (RFC 7517) as published on the JWKS endpoint
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	LoginScopeAccount = "account"
+	LoginScopeIP      = "ip"
+)
+
+// LoginThrottle tracks recent failed logins for one account (by email) or one client IP
+type LoginThrottle struct {
+	Scope        string     `json:"scope"`
+	Subject      string     `json:"subject"`
+	Failures     int        `json:"failures"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+}
+
+// LoginLockedError rejects a login attempt made while the account or IP is delayed or locked out
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("muitas tentativas de login, tente novamente em %d segundos", e.Seconds())
+}
+
+// Seconds is the wait rounded up, as used in the Retry-After header
+func (e *LoginLockedError) Seconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MFAChallengeTTL bounds the time between the password step and the code step of a login
+const MFAChallengeTTL = 5 * time.Minute
+
+var (
+	ErrInvalidMFACode    = errors.New("código de verificação inválido")
+	ErrMFAAlreadyEnabled = errors.New("a verificação em duas etapas já está ativada")
+	ErrMFANotEnabled     = errors.New("a verificação em duas etapas não está ativada")
+	ErrMFANotEnrolled    = errors.New("inicie a ativação da verificação em duas etapas antes de confirmá-la")
+)
+
+// UserMFA holds a user's TOTP secret. It only protects logins once ConfirmedAt is set.
+type UserMFA struct {
+	UserID       int64      `json:"user_id"`
+	Secret       string     `json:"-"` // Base32, as shown to authenticator apps
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"` // Last accepted time step, so a code cannot be replayed
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type MFAEnrollmentResponse struct {
+	Success    bool   `json:"success"`
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"` // Render as a QR code for authenticator apps
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"` // TOTP code, or a recovery code where accepted
+}
+
+type MFARecoveryCodesResponse struct {
+	Success       bool     `json:"success"`
+	RecoveryCodes []string `json:"recovery_codes"` // Shown only once
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"` // TOTP or recovery code
+}
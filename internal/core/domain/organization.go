@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Organization roles, from most to least privileged
+const (
+	OrgRoleOwner  = "owner"  // Manages members, uploads and downloads
+	OrgRoleEditor = "editor" // Uploads and downloads
+	OrgRoleViewer = "viewer" // Lists and downloads
+)
+
+var orgRoleRank = map[string]int{
+	OrgRoleViewer: 1,
+	OrgRoleEditor: 2,
+	OrgRoleOwner:  3,
+}
+
+var (
+	ErrOrganizationNotFound = errors.New("organização não encontrada")
+	ErrInvalidOrgName       = errors.New("nome da organização é obrigatório")
+	ErrInvalidOrgRole       = errors.New("papel inválido, use owner, editor ou viewer")
+	ErrOrgPermissionDenied  = errors.New("seu papel nesta organização não permite esta ação")
+	ErrMemberNotFound       = errors.New("membro não encontrado")
+	ErrAlreadyMember        = errors.New("usuário já é membro da organização")
+	ErrLastOwner            = errors.New("a organização precisa de ao menos um owner")
+)
+
+// IsValidOrgRole reports whether role is one of the organization roles
+func IsValidOrgRole(role string) bool {
+	_, ok := orgRoleRank[role]
+	return ok
+}
+
+// OrgRoleAllows reports whether role grants at least the privileges of required
+func OrgRoleAllows(role, required string) bool {
+	return IsValidOrgRole(role) && orgRoleRank[role] >= orgRoleRank[required]
+}
+
+type Organization struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role,omitempty"` // Role of the requesting user, when listed for them
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64     `json:"organization_id"`
+	UserID         int64     `json:"user_id"`
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type ListOrganizationsResponse struct {
+	Success       bool           `json:"success"`
+	Organizations []Organization `json:"organizations"`
+}
+
+type ListMembersResponse struct {
+	Success bool                 `json:"success"`
+	Members []OrganizationMember `json:"members"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// RateLimitPolicy is a token bucket: Burst requests at once, refilled evenly over Period
+type RateLimitPolicy struct {
+	Name   string // Identifies the bucket family, e.g. "upload"; routes sharing a name share budgets
+	Burst  int
+	Period time.Duration
+}
+
+// RateLimitDecision is the outcome of taking one token from a bucket
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // Until the bucket is full again
+	RetryAfter time.Duration // Until the next token, when not allowed
+}
+
+// RateLimitPolicies are the per-route policies applied by the HTTP adapter
+type RateLimitPolicies struct {
+	Auth     RateLimitPolicy // Login, registration and account recovery, per client IP
+	Upload   RateLimitPolicy // Video uploads, per user
+	Download RateLimitPolicy // ZIP and export downloads, per user or client IP
+	API      RateLimitPolicy // Every other authenticated route, per user
+}
+
+func DefaultRateLimitPolicies() RateLimitPolicies {
+	return RateLimitPolicies{
+		Auth:     RateLimitPolicy{Name: "auth", Burst: 20, Period: time.Minute},
+		Upload:   RateLimitPolicy{Name: "upload", Burst: 10, Period: time.Hour},
+		Download: RateLimitPolicy{Name: "download", Burst: 60, Period: time.Minute},
+		API:      RateLimitPolicy{Name: "api", Burst: 300, Period: time.Minute},
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// AccessTokenTTL is how long an access token stays valid, so signing keys must be kept at
+// least this long after they are retired
+const AccessTokenTTL = 15 * time.Minute
+
+var (
+	ErrInvalidToken   = errors.New("token inválido ou expirado")
+	ErrSessionRevoked = errors.New("sessão encerrada, faça login novamente")
+)
+
+// RefreshToken is one link of a rotating token chain. All tokens descending from the
+// same login share a FamilyID, which is also the session ID carried by access tokens.
+type RefreshToken struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	// AuthenticatedAt is when the user logged in, shared by the whole family
+	AuthenticatedAt time.Time `json:"authenticated_at"`
+}
+
+// Principal is the authenticated identity extracted from a valid access token or API key.
+// Sessions (SessionID set) are unrestricted; API keys are limited to their Scopes.
+type Principal struct {
+	UserID    int64
+	Email     string
+	Role      string
+	SessionID string
+	APIKeyID  int64
+	Scopes    []string
+	// AuthenticatedAt is when the session logged in; zero for API keys
+	AuthenticatedAt time.Time
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	if p.APIKeyID == 0 {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
@@ -1,18 +1,43 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+var (
+	ErrUserNotFound    = errors.New("usuário não encontrado")
+	ErrEmailTaken      = errors.New("usuário já cadastrado com este e-mail")
+	ErrInvalidPassword = errors.New("senha atual incorreta")
+	ErrInvalidName     = errors.New("o nome não pode ficar em branco")
+	// ErrReauthenticationRequired asks accounts without a password to log in again before a sensitive operation
+	ErrReauthenticationRequired = errors.New("faça login novamente para confirmar esta operação")
+)
 
 type User struct {
-	ID        int64     `json:"id"`
-	Email     string    `json:"email"`
-	Password  string    `json:"password,omitempty"` // omitempty so we don't return it in JSON
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int64     `json:"id"`
+	Email         string    `json:"email"`
+	Password      string    `json:"password,omitempty"` // omitempty so we don't return it in JSON
+	Name          string    `json:"name"`
+	Role          string    `json:"role"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
+// AuthResponse carries the session tokens, or only an MFA challenge when the account
+// has two-factor authentication enabled.
 type AuthResponse struct {
-	User  User   `json:"user"`
-	Token string `json:"token"`
+	User         User   `json:"user"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"` // Access token lifetime in seconds
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"` // Exchange at /auth/mfa/verify with a code
 }
 
 type LoginRequest struct {
@@ -25,3 +50,25 @@ type RegisterRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 	Name     string `json:"name" binding:"required"`
 }
+
+type ListUsersResponse struct {
+	Success bool   `json:"success"`
+	Users   []User `json:"users"`
+}
+
+// UpdateProfileRequest changes only the fields that are present
+type UpdateProfileRequest struct {
+	Name  *string `json:"name" binding:"omitempty,min=1"`
+	Email *string `json:"email" binding:"omitempty,email"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// DeleteAccountRequest confirms the deletion. Accounts created through SSO have no password and
+// must have logged in within the last few minutes instead.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
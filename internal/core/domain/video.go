@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 const (
 	StatusPending    = "PENDING"
@@ -9,16 +12,38 @@ const (
 	StatusFailed     = "FAILED"
 )
 
+var (
+	ErrVideoNotFound     = errors.New("vídeo não encontrado")
+	ErrVideoNotProcessed = errors.New("vídeo ainda não foi processado")
+	ErrVideoFinished     = errors.New("o processamento deste vídeo já foi finalizado")
+	// ErrStorageKeyTaken is returned by repositories for a storage key that is already in use
+	ErrStorageKeyTaken = errors.New("chave de armazenamento já está em uso")
+)
+
+// ErrCodeAborted is the error code of a video failed by an administrator
+const ErrCodeAborted = "ERR_ABORTED"
+
 type Video struct {
-	ID         int64     `json:"id"`
-	UserID     int64     `json:"user_id"`
-	Filename   string    `json:"filename"`
-	Status     string    `json:"status"`
-	ZipPath    string    `json:"zip_path,omitempty"`
-	FrameCount int       `json:"frame_count"`
-	Message    string    `json:"message,omitempty"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID                   int64      `json:"id"`
+	UserID               int64      `json:"user_id"`                   // Uploader; 0 once an organization video's uploader deleted their account
+	OrganizationID       *int64     `json:"organization_id,omitempty"` // Owning organization, if shared with a team
+	OriginalFilename     string     `json:"original_filename"`
+	StorageKey           string     `json:"-"` // Sanitized key used by storage and the worker, never derived from user input
+	Status               string     `json:"status"`
+	ZipPath              string     `json:"zip_path,omitempty"`
+	FrameCount           int        `json:"frame_count"`
+	Message              string     `json:"message,omitempty"`
+	ErrorCode            string     `json:"error_code,omitempty"`             // Machine-readable reason of a FAILED video
+	WorkerID             string     `json:"worker_id,omitempty"`              // Worker that picked the video up
+	OutputSize           int64      `json:"output_size,omitempty"`            // Size of the ZIP in bytes
+	ProcessingStartedAt  *time.Time `json:"processing_started_at,omitempty"`  // Set when a worker picks the video up
+	ProcessingFinishedAt *time.Time `json:"processing_finished_at,omitempty"` // Set when the video is COMPLETED or FAILED
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+type FailVideoRequest struct {
+	Reason string `json:"reason"`
 }
 
 type ProcessingResult struct {
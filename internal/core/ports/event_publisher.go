@@ -1,5 +1,10 @@
 package ports
 
+import "context"
+
 type EventPublisher interface {
-	PublishUploadEvent(videoID int64, filename string) error
+	HealthChecker
+	PublishUploadEvent(ctx context.Context, videoID int64, filename string) error
+	// Close flushes pending events and releases the connection.
+	Close() error
 }
@@ -1,45 +1,339 @@
 package ports
 
 import (
+	"context"
 	"io"
+	"time"
 	"video-processor/internal/core/domain"
 )
 
 // VideoUseCase is the Inbound Port
 type VideoUseCase interface {
-	UploadAndProcess(userID int64, filename string, file io.Reader) (domain.ProcessingResult, error)
-	ListProcessedFiles() ([]domain.FileInfo, error)
-	GetVideosByUserID(userID int64) ([]domain.Video, error)
+	// UploadAndProcess stores a video for the user, or for organizationID when set, which
+	// requires at least the editor role
+	UploadAndProcess(ctx context.Context, userID int64, organizationID *int64, filename string, file io.Reader) (domain.ProcessingResult, error)
+	ListProcessedFiles(ctx context.Context) ([]domain.FileInfo, error)
+	// GetVideosByUserID lists the user's own videos and those of their organizations
+	GetVideosByUserID(ctx context.Context, userID int64) ([]domain.Video, error)
+	// GetUserVideo returns a video the user owns or can see through an organization
+	GetUserVideo(ctx context.Context, userID, videoID int64) (*domain.Video, error)
+	ListAllVideos(ctx context.Context) ([]domain.Video, error)
+	FailVideo(ctx context.Context, videoID int64, reason string) (*domain.Video, error)
 }
 
 // Storage is the Outbound Port for file operations
 type Storage interface {
-	SaveUpload(filename string, data io.Reader) (string, error)
-	SaveZip(zipFilename string, files []string) error
-	DeleteFile(path string) error
-	DeleteDir(path string) error
-	ListOutputs() ([]domain.FileInfo, error)
+	HealthChecker
+	SaveUpload(ctx context.Context, filename string, data io.Reader) (string, error)
+	SaveZip(ctx context.Context, zipFilename string, files []string) error
+	// SaveTemp writes a scratch file, e.g. before zipping it; filename may include a subdirectory
+	SaveTemp(ctx context.Context, filename string, data io.Reader) (string, error)
+	DeleteFile(ctx context.Context, path string) error
+	DeleteDir(ctx context.Context, path string) error
+	ListOutputs(ctx context.Context) ([]domain.FileInfo, error)
 	GetOutputPath(filename string) string
 	GetUploadPath(filename string) string
 }
 
+// UnitOfWork is the Outbound Port that groups repository calls atomically
+type UnitOfWork interface {
+	// Do runs fn in a transaction, committing when it returns nil and rolling back otherwise.
+	// Repository calls made with the ctx given to fn take part in the transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// HealthChecker is implemented by outbound adapters that can probe their dependency
+type HealthChecker interface {
+	// CheckHealth returns an error when the dependency cannot serve requests
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheckFunc adapts an ordinary function to a HealthChecker
+type HealthCheckFunc func(ctx context.Context) error
+
+func (f HealthCheckFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
+// HealthUseCase is the Inbound Port for the orchestrator's readiness probe
+type HealthUseCase interface {
+	// Readiness probes every dependency concurrently
+	Readiness(ctx context.Context) domain.ReadinessReport
+	// BeginShutdown reports not ready from now on so traffic drains before the server stops
+	BeginShutdown()
+}
+
+// RateLimiter is the Outbound Port for request rate limiting. Implementations may keep buckets
+// in memory or in a shared store so limits hold across instances.
+type RateLimiter interface {
+	// Allow takes one token from the bucket identified by key under the given policy
+	Allow(ctx context.Context, key string, policy domain.RateLimitPolicy) (domain.RateLimitDecision, error)
+}
+
 // VideoRepository is the Outbound Port for video data persistence
 type VideoRepository interface {
-	Create(video *domain.Video) error
-	Update(video *domain.Video) error
-	GetByID(id int64) (*domain.Video, error)
-	GetByUserID(userID int64) ([]domain.Video, error)
+	Create(ctx context.Context, video *domain.Video) error
+	Update(ctx context.Context, video *domain.Video) error
+	GetByID(ctx context.Context, id int64) (*domain.Video, error)
+	// GetByUserID lists the videos uploaded by the user
+	GetByUserID(ctx context.Context, userID int64) ([]domain.Video, error)
+	// ListAccessibleByUserID lists the user's personal videos and those of organizations they belong to
+	ListAccessibleByUserID(ctx context.Context, userID int64) ([]domain.Video, error)
+	List(ctx context.Context) ([]domain.Video, error)
+	// GetStatusHistoryByUserID returns every status change of the user's videos, oldest first
+	GetStatusHistoryByUserID(ctx context.Context, userID int64) ([]domain.VideoStatusEvent, error)
+}
+
+// OrganizationUseCase is the Inbound Port for organizations and their members
+type OrganizationUseCase interface {
+	// CreateOrganization creates an organization with the user as its owner
+	CreateOrganization(ctx context.Context, userID int64, name string) (*domain.Organization, error)
+	ListOrganizations(ctx context.Context, userID int64) ([]domain.Organization, error)
+	ListMembers(ctx context.Context, userID, organizationID int64) ([]domain.OrganizationMember, error)
+	AddMember(ctx context.Context, actorID, organizationID int64, email, role string) (*domain.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, actorID, organizationID, userID int64, role string) error
+	// RemoveMember removes a member on behalf of an owner, or lets a member leave
+	RemoveMember(ctx context.Context, actorID, organizationID, userID int64) error
+}
+
+// OrganizationRepository is the Outbound Port for organization persistence
+type OrganizationRepository interface {
+	// Create stores the organization and makes ownerID its first owner
+	Create(ctx context.Context, org *domain.Organization, ownerID int64) error
+	GetByID(ctx context.Context, id int64) (*domain.Organization, error)
+	ListByUserID(ctx context.Context, userID int64) ([]domain.Organization, error)
+	GetMember(ctx context.Context, organizationID, userID int64) (*domain.OrganizationMember, error)
+	ListMembers(ctx context.Context, organizationID int64) ([]domain.OrganizationMember, error)
+	// AddMember returns domain.ErrAlreadyMember if the user is already a member
+	AddMember(ctx context.Context, member *domain.OrganizationMember) error
+	UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) error
+	RemoveMember(ctx context.Context, organizationID, userID int64) error
+	// CountOwners locks the owner rows until the unit of work running in ctx ends, so a
+	// concurrent change of another owner waits and then counts again
+	CountOwners(ctx context.Context, organizationID int64) (int, error)
 }
 
 // UserUseCase is the Inbound Port for user logic
 type UserUseCase interface {
-	Register(email, password, name string) (domain.AuthResponse, error)
-	Login(email, password string) (domain.AuthResponse, error)
+	Register(ctx context.Context, email, password, name string) (domain.AuthResponse, error)
+	// Login rejects attempts from accounts or client IPs with too many recent failures. Accounts
+	// with two-factor authentication get an MFA challenge instead of tokens.
+	Login(ctx context.Context, email, password, clientIP string) (domain.AuthResponse, error)
+	// VerifyMFA exchanges an MFA challenge token and a TOTP or recovery code for a session
+	VerifyMFA(ctx context.Context, mfaToken, code, clientIP string) (domain.AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (domain.AuthResponse, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ValidateToken(ctx context.Context, accessToken string) (*domain.Principal, error)
+	ListUsers(ctx context.Context) ([]domain.User, error)
+	// LoginWithIdentity signs in the user linked to an external identity, linking or
+	// provisioning one by verified email on first login
+	LoginWithIdentity(ctx context.Context, identity domain.ExternalIdentity) (domain.AuthResponse, error)
+	// UnlockAccount clears the failed login lockout of a user on behalf of an admin
+	UnlockAccount(ctx context.Context, actorID, userID int64) error
 }
 
 // UserRepository is the Outbound Port for user data persistence
 type UserRepository interface {
-	Create(user *domain.User) error
-	GetByEmail(email string) (*domain.User, error)
-	GetByID(id int64) (*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetByID(ctx context.Context, id int64) (*domain.User, error)
+	List(ctx context.Context) ([]domain.User, error)
+	// Update saves name, email and email_verified
+	Update(ctx context.Context, user *domain.User) error
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, id int64) error
+	// Delete removes the user with its dependent rows and personal videos. Organization videos
+	// it uploaded stay with the organization, with a zero UserID.
+	Delete(ctx context.Context, id int64) error
+}
+
+// ProfileUseCase is the Inbound Port for users managing their own account
+type ProfileUseCase interface {
+	GetProfile(ctx context.Context, userID int64) (*domain.User, error)
+	// UpdateProfile changes name and/or email. A new email must be verified again.
+	UpdateProfile(ctx context.Context, userID int64, req domain.UpdateProfileRequest) (*domain.User, error)
+	// ChangePassword requires the current password and ends every other session
+	ChangePassword(ctx context.Context, userID int64, sessionID, currentPassword, newPassword string) error
+	// DeleteAccount removes the user with all their data and then their files from storage.
+	// Videos uploaded to an organization stay with it. Accounts without a password must have
+	// logged in recently, at authenticatedAt, instead of confirming the password.
+	DeleteAccount(ctx context.Context, userID int64, authenticatedAt time.Time, password string) error
+}
+
+// MFAUseCase is the Inbound Port for managing TOTP two-factor authentication
+type MFAUseCase interface {
+	// BeginEnrollment generates a new secret, replacing any unconfirmed one
+	BeginEnrollment(ctx context.Context, userID int64) (domain.MFAEnrollmentResponse, error)
+	// ConfirmEnrollment enables MFA once the user proves the authenticator works, returning recovery codes
+	ConfirmEnrollment(ctx context.Context, userID int64, code string) ([]string, error)
+	// Disable turns MFA off, given a valid TOTP or recovery code
+	Disable(ctx context.Context, userID int64, code string) error
+}
+
+// MFARepository is the Outbound Port for TOTP secrets and recovery codes
+type MFARepository interface {
+	GetByUserID(ctx context.Context, userID int64) (*domain.UserMFA, error)
+	// SavePending stores an unconfirmed secret, replacing a previous unconfirmed one
+	SavePending(ctx context.Context, mfa *domain.UserMFA) error
+	// Confirm enables MFA and stores the hashed recovery codes
+	Confirm(ctx context.Context, userID int64, recoveryCodeHashes []string) error
+	// MarkStepUsed records an accepted time step, returning false if it (or a later one) was already used
+	MarkStepUsed(ctx context.Context, userID, step int64) (bool, error)
+	// UseRecoveryCode consumes an unused recovery code, returning false if none matches
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	// Delete removes the secret and all recovery codes
+	Delete(ctx context.Context, userID int64) error
+}
+
+// DataExportUseCase is the Inbound Port for personal data exports
+type DataExportUseCase interface {
+	// RequestExport starts building the archive in the background and emails a download link
+	// when done. A request already in progress is returned instead of starting another.
+	RequestExport(ctx context.Context, userID int64) (*domain.DataExport, error)
+	GetExport(ctx context.Context, userID, exportID int64) (*domain.DataExport, error)
+	// OpenDownload resolves an emailed download token to the archive path
+	OpenDownload(ctx context.Context, token string) (string, error)
+	// PurgeExpired deletes archives whose download window has passed, and old failed exports
+	PurgeExpired(ctx context.Context) error
+	// FailInterrupted marks exports pending for longer than a build may take as failed, so a
+	// build lost to a restart does not block new requests
+	FailInterrupted(ctx context.Context) error
+	// Wait blocks until the archives being built are done, or ctx is
+	Wait(ctx context.Context) error
+}
+
+// DataExportRepository is the Outbound Port for data export persistence
+type DataExportRepository interface {
+	Create(ctx context.Context, export *domain.DataExport) error
+	Update(ctx context.Context, export *domain.DataExport) error
+	GetByID(ctx context.Context, id int64) (*domain.DataExport, error)
+	GetPendingByUserID(ctx context.Context, userID int64) (*domain.DataExport, error)
+	// ListByUserID locks the rows until the unit of work running in ctx ends, so a build
+	// completing meanwhile waits and then finds its export gone
+	ListByUserID(ctx context.Context, userID int64) ([]domain.DataExport, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DataExport, error)
+	// ListExpired returns exports whose download window closed before expiredBefore and failed
+	// exports requested before failedBefore
+	ListExpired(ctx context.Context, expiredBefore, failedBefore time.Time) ([]domain.DataExport, error)
+	// FailPendingBefore marks pending exports requested before createdBefore as failed with
+	// reason, returning how many
+	FailPendingBefore(ctx context.Context, createdBefore time.Time, reason string) (int64, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// LoginThrottleRepository is the Outbound Port for failed login tracking
+type LoginThrottleRepository interface {
+	Get(ctx context.Context, scope, subject string) (*domain.LoginThrottle, error)
+	// RegisterFailure adds a failure and returns the new count. Failures older than
+	// resetBefore are forgotten first.
+	RegisterFailure(ctx context.Context, scope, subject string, resetBefore time.Time) (int, error)
+	Lock(ctx context.Context, scope, subject string, until time.Time) error
+	Reset(ctx context.Context, scope, subject string) error
+}
+
+// AuditUseCase is the Inbound Port for the security audit log
+type AuditUseCase interface {
+	Record(ctx context.Context, event *domain.AuditEvent) error
+	Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+	// PurgeExpired deletes events older than the retention window and returns how many
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// AuditRepository is the Outbound Port for the append-only audit log
+type AuditRepository interface {
+	Record(ctx context.Context, event *domain.AuditEvent) error
+	// Query returns matching events, newest first
+	Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AccountUseCase is the Inbound Port for email-based account recovery and verification
+type AccountUseCase interface {
+	// RequestPasswordReset emails a reset link. Unknown addresses are silently ignored.
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	SendVerificationEmail(ctx context.Context, userID int64) error
+	VerifyEmail(ctx context.Context, token string) error
+	IsEmailVerified(ctx context.Context, userID int64) (bool, error)
+}
+
+// AccountTokenRepository is the Outbound Port for password reset and email verification tokens
+type AccountTokenRepository interface {
+	Create(ctx context.Context, token *domain.AccountToken) error
+	// Consume marks an unused token as used and returns it, or nil if it is unknown or already used
+	Consume(ctx context.Context, tokenHash, purpose string) (*domain.AccountToken, error)
+	// InvalidateForUser marks every unused token of the user with the given purpose as used
+	InvalidateForUser(ctx context.Context, userID int64, purpose string) error
+}
+
+// Mailer is the Outbound Port for sending emails
+type Mailer interface {
+	Send(email domain.Email) error
+}
+
+// RefreshTokenRepository is the Outbound Port for refresh token persistence
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// MarkRotated flags an active token as used, returning false if it was already rotated or revoked
+	MarkRotated(ctx context.Context, id int64) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	IsFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+	// RevokeAllForUser ends every session of the user except keepFamilyID, if not empty
+	RevokeAllForUser(ctx context.Context, userID int64, keepFamilyID string) error
+}
+
+// APIKeyUseCase is the Inbound Port for machine-to-machine credentials
+type APIKeyUseCase interface {
+	Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (domain.CreateAPIKeyResponse, error)
+	List(ctx context.Context, userID int64) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, userID, keyID int64) error
+	Authenticate(ctx context.Context, rawKey string) (*domain.Principal, error)
+}
+
+// APIKeyRepository is the Outbound Port for API key persistence
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	ListByUserID(ctx context.Context, userID int64) ([]domain.APIKey, error)
+	// Revoke returns false when the key does not exist, belongs to another user or is already revoked
+	Revoke(ctx context.Context, userID, keyID int64) (bool, error)
+	TouchLastUsed(ctx context.Context, keyID int64) error
+}
+
+// TokenSigner is the Outbound Port that signs and verifies the JWTs issued by the API
+type TokenSigner interface {
+	Sign(claims map[string]interface{}) (string, error)
+	// Verify checks the signature and expiration, returning the token claims
+	Verify(token string) (map[string]interface{}, error)
+	// PublicKeys returns the verification keys, empty for symmetric algorithms
+	PublicKeys() domain.JWKS
+}
+
+// SSOUseCase is the Inbound Port for OpenID Connect single sign-on
+type SSOUseCase interface {
+	// BeginLogin returns the IdP authorization URL and the state bound to it
+	BeginLogin(ctx context.Context) (authURL string, state string, err error)
+	CompleteLogin(ctx context.Context, state, code string) (domain.AuthResponse, error)
+}
+
+// IdentityProvider is the Outbound Port for an OpenID Connect provider
+type IdentityProvider interface {
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	// Exchange redeems the authorization code and verifies the ID token, including its nonce
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalIdentity, error)
+}
+
+// OIDCStateRepository is the Outbound Port for pending single sign-on logins
+type OIDCStateRepository interface {
+	Create(ctx context.Context, state *domain.OIDCLoginState) error
+	// Consume deletes and returns the state, so it cannot be replayed
+	Consume(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+// UserIdentityRepository is the Outbound Port for links between users and external identities
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *domain.UserIdentity) error
+	GetBySubject(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error)
 }
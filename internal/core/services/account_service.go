@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 24 * time.Hour
+)
+
+type accountService struct {
+	users    ports.UserRepository
+	tokens   ports.AccountTokenRepository
+	sessions ports.RefreshTokenRepository
+	mailer   ports.Mailer
+	baseURL  string
+}
+
+// NewAccountService builds the recovery and verification flows. baseURL is the public
+// address used in the links sent by email.
+func NewAccountService(users ports.UserRepository, tokens ports.AccountTokenRepository, sessions ports.RefreshTokenRepository, mailer ports.Mailer, baseURL string) ports.AccountUseCase {
+	return &accountService{
+		users:    users,
+		tokens:   tokens,
+		sessions: sessions,
+		mailer:   mailer,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *accountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		// Same outcome as a known address, so the endpoint cannot be used to enumerate accounts
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, domain.TokenPurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.baseURL + "/auth/reset-password?token=" + url.QueryEscape(token)
+	return s.mailer.Send(domain.Email{
+		To:      user.Email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf("Olá, %s!\n\nRecebemos um pedido para redefinir sua senha. Acesse o link abaixo em até %d minutos:\n\n%s\n\nSe você não fez esse pedido, ignore este e-mail.\n",
+			user.Name, int(passwordResetTTL.Minutes()), link),
+	})
+}
+
+func (s *accountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	stored, err := s.consumeToken(ctx, token, domain.TokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.users.UpdatePassword(ctx, stored.UserID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	// Whoever knew the old password must not keep a session
+	if err := s.sessions.RevokeAllForUser(ctx, stored.UserID, ""); err != nil {
+		return err
+	}
+	// Following the emailed link proves ownership of the address as well
+	return s.users.MarkEmailVerified(ctx, stored.UserID)
+}
+
+func (s *accountService) SendVerificationEmail(ctx context.Context, userID int64) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("usuário não encontrado")
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, domain.TokenPurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.baseURL + "/auth/verify-email?token=" + url.QueryEscape(token)
+	return s.mailer.Send(domain.Email{
+		To:      user.Email,
+		Subject: "Confirme seu e-mail",
+		Body: fmt.Sprintf("Olá, %s!\n\nConfirme seu endereço de e-mail acessando o link abaixo em até %d horas:\n\n%s\n",
+			user.Name, int(emailVerificationTTL.Hours()), link),
+	})
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	stored, err := s.consumeToken(ctx, token, domain.TokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+	return s.users.MarkEmailVerified(ctx, stored.UserID)
+}
+
+func (s *accountService) IsEmailVerified(ctx context.Context, userID int64) (bool, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user != nil && user.EmailVerified, nil
+}
+
+// issueToken replaces any pending token of the same purpose, so only the latest link works
+func (s *accountService) issueToken(ctx context.Context, userID int64, purpose string, ttl time.Duration) (string, error) {
+	if err := s.tokens.InvalidateForUser(ctx, userID, purpose); err != nil {
+		return "", err
+	}
+
+	raw, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.tokens.Create(ctx, &domain.AccountToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+func (s *accountService) consumeToken(ctx context.Context, raw, purpose string) (*domain.AccountToken, error) {
+	stored, err := s.tokens.Consume(ctx, hashToken(raw), purpose)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil || time.Now().After(stored.ExpiresAt) {
+		return nil, domain.ErrInvalidAccountToken
+	}
+	return stored, nil
+}
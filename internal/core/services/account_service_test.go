@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAccountService_RequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("emails a link with the unhashed token", func(t *testing.T) {
+		users := new(MockUserRepository)
+		tokens := new(MockAccountTokenRepository)
+		mailer := new(MockMailer)
+		service := NewAccountService(users, tokens, nil, mailer, "https://app.example.com/")
+
+		var stored *domain.AccountToken
+		users.On("GetByEmail", mock.Anything, "test@example.com").Return(&domain.User{ID: 1, Email: "test@example.com", Name: "Test"}, nil)
+		tokens.On("InvalidateForUser", mock.Anything, int64(1), domain.TokenPurposePasswordReset).Return(nil)
+		tokens.On("Create", mock.Anything, mock.AnythingOfType("*domain.AccountToken")).Return(nil).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*domain.AccountToken)
+		})
+		mailer.On("Send", mock.AnythingOfType("domain.Email")).Return(nil)
+
+		err := service.RequestPasswordReset(ctx, "test@example.com")
+
+		assert.NoError(t, err)
+		sent := mailer.Calls[0].Arguments.Get(0).(domain.Email)
+		assert.Equal(t, "test@example.com", sent.To)
+
+		_, raw, found := strings.Cut(sent.Body, "https://app.example.com/auth/reset-password?token=")
+		assert.True(t, found)
+		raw, _, _ = strings.Cut(raw, "\n")
+		assert.Equal(t, hashToken(raw), stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(passwordResetTTL), stored.ExpiresAt, time.Minute)
+		tokens.AssertExpectations(t)
+	})
+
+	t.Run("unknown email is silently ignored", func(t *testing.T) {
+		users := new(MockUserRepository)
+		mailer := new(MockMailer)
+		service := NewAccountService(users, nil, nil, mailer, "http://localhost:8080")
+
+		users.On("GetByEmail", mock.Anything, "missing@example.com").Return(nil, nil)
+
+		assert.NoError(t, service.RequestPasswordReset(ctx, "missing@example.com"))
+		mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success updates password and revokes sessions", func(t *testing.T) {
+		users := new(MockUserRepository)
+		tokens := new(MockAccountTokenRepository)
+		sessions := new(MockRefreshTokenRepository)
+		service := NewAccountService(users, tokens, sessions, nil, "http://localhost:8080")
+
+		tokens.On("Consume", mock.Anything, hashToken("reset-token"), domain.TokenPurposePasswordReset).
+			Return(&domain.AccountToken{UserID: 1, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		users.On("UpdatePassword", mock.Anything, int64(1), mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")) == nil
+		})).Return(nil)
+		sessions.On("RevokeAllForUser", mock.Anything, int64(1), "").Return(nil)
+		users.On("MarkEmailVerified", mock.Anything, int64(1)).Return(nil)
+
+		err := service.ResetPassword(ctx, "reset-token", "new-password")
+
+		assert.NoError(t, err)
+		users.AssertExpectations(t)
+		sessions.AssertExpectations(t)
+	})
+
+	t.Run("used or unknown token", func(t *testing.T) {
+		users := new(MockUserRepository)
+		tokens := new(MockAccountTokenRepository)
+		service := NewAccountService(users, tokens, nil, nil, "http://localhost:8080")
+
+		tokens.On("Consume", mock.Anything, hashToken("used"), domain.TokenPurposePasswordReset).Return(nil, nil)
+
+		err := service.ResetPassword(ctx, "used", "new-password")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAccountToken)
+		users.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		users := new(MockUserRepository)
+		tokens := new(MockAccountTokenRepository)
+		service := NewAccountService(users, tokens, nil, nil, "http://localhost:8080")
+
+		tokens.On("Consume", mock.Anything, hashToken("expired"), domain.TokenPurposePasswordReset).
+			Return(&domain.AccountToken{UserID: 1, ExpiresAt: time.Now().Add(-time.Second)}, nil)
+
+		err := service.ResetPassword(ctx, "expired", "new-password")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAccountToken)
+		users.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountService_EmailVerification(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends verification link", func(t *testing.T) {
+		users := new(MockUserRepository)
+		tokens := new(MockAccountTokenRepository)
+		mailer := new(MockMailer)
+		service := NewAccountService(users, tokens, nil, mailer, "http://localhost:8080")
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "test@example.com"}, nil)
+		tokens.On("InvalidateForUser", mock.Anything, int64(1), domain.TokenPurposeEmailVerification).Return(nil)
+		tokens.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.AccountToken) bool {
+			return token.Purpose == domain.TokenPurposeEmailVerification
+		})).Return(nil)
+		mailer.On("Send", mock.MatchedBy(func(email domain.Email) bool {
+			return strings.Contains(email.Body, "http://localhost:8080/auth/verify-email?token=")
+		})).Return(nil)
+
+		assert.NoError(t, service.SendVerificationEmail(ctx, 1))
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		users := new(MockUserRepository)
+		mailer := new(MockMailer)
+		service := NewAccountService(users, nil, nil, mailer, "http://localhost:8080")
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, EmailVerified: true}, nil)
+
+		assert.NoError(t, service.SendVerificationEmail(ctx, 1))
+		mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("verify marks the user", func(t *testing.T) {
+		users := new(MockUserRepository)
+		tokens := new(MockAccountTokenRepository)
+		service := NewAccountService(users, tokens, nil, nil, "http://localhost:8080")
+
+		tokens.On("Consume", mock.Anything, hashToken("verify-token"), domain.TokenPurposeEmailVerification).
+			Return(&domain.AccountToken{UserID: 3, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		users.On("MarkEmailVerified", mock.Anything, int64(3)).Return(nil)
+
+		assert.NoError(t, service.VerifyEmail(ctx, "verify-token"))
+		users.AssertExpectations(t)
+	})
+
+	t.Run("reset token cannot verify email", func(t *testing.T) {
+		tokens := new(MockAccountTokenRepository)
+		service := NewAccountService(nil, tokens, nil, nil, "http://localhost:8080")
+
+		tokens.On("Consume", mock.Anything, hashToken("reset-token"), domain.TokenPurposeEmailVerification).Return(nil, nil)
+
+		assert.ErrorIs(t, service.VerifyEmail(ctx, "reset-token"), domain.ErrInvalidAccountToken)
+	})
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+const (
+	// apiKeyPrefixLength is the length of the public lookup part, marker included
+	apiKeyPrefixLength = len(domain.APIKeyMarker) + 12
+	// lastUsedResolution avoids a database write on every single request made with the same key
+	lastUsedResolution = time.Minute
+)
+
+type apiKeyService struct {
+	repo  ports.APIKeyRepository
+	users ports.UserRepository
+}
+
+func NewAPIKeyService(repo ports.APIKeyRepository, users ports.UserRepository) ports.APIKeyUseCase {
+	return &apiKeyService{
+		repo:  repo,
+		users: users,
+	}
+}
+
+func (s *apiKeyService) Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (domain.CreateAPIKeyResponse, error) {
+	if strings.TrimSpace(name) == "" {
+		return domain.CreateAPIKeyResponse{}, domain.ErrInvalidAPIKeyName
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return domain.CreateAPIKeyResponse{}, domain.ErrInvalidAPIKeyExpiry
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return domain.CreateAPIKeyResponse{}, err
+	}
+	if user == nil {
+		return domain.CreateAPIKeyResponse{}, domain.ErrUserNotFound
+	}
+
+	scopes, err = normalizeScopes(scopes, user.Role)
+	if err != nil {
+		return domain.CreateAPIKeyResponse{}, err
+	}
+
+	lookup := make([]byte, (apiKeyPrefixLength-len(domain.APIKeyMarker))/2)
+	if _, err := rand.Read(lookup); err != nil {
+		return domain.CreateAPIKeyResponse{}, err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return domain.CreateAPIKeyResponse{}, err
+	}
+
+	prefix := domain.APIKeyMarker + hex.EncodeToString(lookup)
+	rawKey := prefix + "_" + secret
+
+	key := &domain.APIKey{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    prefix,
+		KeyHash:   hashToken(rawKey),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		return domain.CreateAPIKeyResponse{}, err
+	}
+
+	return domain.CreateAPIKeyResponse{
+		Success: true,
+		Key:     rawKey,
+		APIKey:  *key,
+	}, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, userID, keyID int64) error {
+	revoked, err := s.repo.Revoke(ctx, userID, keyID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*domain.Principal, error) {
+	if !strings.HasPrefix(rawKey, domain.APIKeyMarker) || len(rawKey) <= apiKeyPrefixLength+1 || rawKey[apiKeyPrefixLength] != '_' {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByPrefix(ctx, rawKey[:apiKeyPrefixLength])
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashToken(rawKey))) != 1 {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && now.After(*key.ExpiresAt)) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	user, err := s.users.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > lastUsedResolution {
+		if err := s.repo.TouchLastUsed(ctx, key.ID); err != nil {
+			log.Printf("⚠️ Erro ao atualizar último uso da chave de API %d: %v", key.ID, err)
+		}
+	}
+
+	return &domain.Principal{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Role:     user.Role,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}, nil
+}
+
+// normalizeScopes applies the default scopes, drops duplicates and rejects unknown scopes
+// or scopes the owner is not entitled to.
+func normalizeScopes(scopes []string, role string) ([]string, error) {
+	if len(scopes) == 0 {
+		return append([]string(nil), domain.DefaultAPIKeyScopes...), nil
+	}
+
+	seen := make(map[string]bool)
+	var result []string
+	for _, scope := range scopes {
+		switch scope {
+		case domain.ScopeVideosRead, domain.ScopeVideosWrite:
+		case domain.ScopeAdmin:
+			if role != domain.RoleAdmin {
+				return nil, domain.ErrInvalidScope
+			}
+		default:
+			return nil, domain.ErrInvalidScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
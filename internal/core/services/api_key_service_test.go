@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAPIKeyService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success with default scopes", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		users := new(MockUserRepository)
+		service := NewAPIKeyService(repo, users)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Role: domain.RoleUser}, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.APIKey")).Return(nil)
+
+		resp, err := service.Create(ctx, 1, "ci pipeline", nil, nil)
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.Regexp(t, `^fxk_[0-9a-f]{12}_[A-Za-z0-9_-]{43}$`, resp.Key)
+		assert.Equal(t, resp.Key[:apiKeyPrefixLength], resp.APIKey.Prefix)
+		assert.Equal(t, hashToken(resp.Key), resp.APIKey.KeyHash)
+		assert.Equal(t, domain.DefaultAPIKeyScopes, resp.APIKey.Scopes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("admin scope requires admin role", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		users := new(MockUserRepository)
+		service := NewAPIKeyService(repo, users)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Role: domain.RoleUser}, nil)
+
+		_, err := service.Create(ctx, 1, "ci", []string{domain.ScopeAdmin}, nil)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidScope)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		users := new(MockUserRepository)
+		service := NewAPIKeyService(new(MockAPIKeyRepository), users)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Role: domain.RoleAdmin}, nil)
+
+		_, err := service.Create(ctx, 1, "ci", []string{"videos:delete"}, nil)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidScope)
+	})
+
+	t.Run("expiration in the past", func(t *testing.T) {
+		service := NewAPIKeyService(new(MockAPIKeyRepository), new(MockUserRepository))
+
+		past := time.Now().Add(-time.Hour)
+		_, err := service.Create(ctx, 1, "ci", nil, &past)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKeyExpiry)
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		service := NewAPIKeyService(new(MockAPIKeyRepository), new(MockUserRepository))
+
+		_, err := service.Create(ctx, 1, "  ", nil, nil)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKeyName)
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	const rawKey = "fxk_0123456789ab_c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3JldA"
+
+	t.Run("valid key", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		users := new(MockUserRepository)
+		service := NewAPIKeyService(repo, users)
+
+		key := &domain.APIKey{ID: 3, UserID: 1, Prefix: "fxk_0123456789ab", KeyHash: hashToken(rawKey), Scopes: []string{domain.ScopeVideosWrite}}
+		repo.On("GetByPrefix", mock.Anything, "fxk_0123456789ab").Return(key, nil)
+		repo.On("TouchLastUsed", mock.Anything, int64(3)).Return(nil)
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "ci@example.com", Role: domain.RoleUser}, nil)
+
+		principal, err := service.Authenticate(ctx, rawKey)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), principal.UserID)
+		assert.Equal(t, int64(3), principal.APIKeyID)
+		assert.True(t, principal.HasScope(domain.ScopeVideosWrite))
+		assert.False(t, principal.HasScope(domain.ScopeVideosRead))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("recently used key skips last-used update", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		users := new(MockUserRepository)
+		service := NewAPIKeyService(repo, users)
+
+		lastUsed := time.Now().Add(-10 * time.Second)
+		key := &domain.APIKey{ID: 3, UserID: 1, KeyHash: hashToken(rawKey), LastUsedAt: &lastUsed}
+		repo.On("GetByPrefix", mock.Anything, "fxk_0123456789ab").Return(key, nil)
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+
+		_, err := service.Authenticate(ctx, rawKey)
+
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(repo, nil)
+
+		key := &domain.APIKey{ID: 3, UserID: 1, KeyHash: hashToken("fxk_0123456789ab_other")}
+		repo.On("GetByPrefix", mock.Anything, "fxk_0123456789ab").Return(key, nil)
+
+		_, err := service.Authenticate(ctx, rawKey)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(repo, nil)
+
+		revokedAt := time.Now()
+		key := &domain.APIKey{ID: 3, UserID: 1, KeyHash: hashToken(rawKey), RevokedAt: &revokedAt}
+		repo.On("GetByPrefix", mock.Anything, "fxk_0123456789ab").Return(key, nil)
+
+		_, err := service.Authenticate(ctx, rawKey)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(repo, nil)
+
+		expiresAt := time.Now().Add(-time.Minute)
+		key := &domain.APIKey{ID: 3, UserID: 1, KeyHash: hashToken(rawKey), ExpiresAt: &expiresAt}
+		repo.On("GetByPrefix", mock.Anything, "fxk_0123456789ab").Return(key, nil)
+
+		_, err := service.Authenticate(ctx, rawKey)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("malformed key", func(t *testing.T) {
+		service := NewAPIKeyService(nil, nil)
+
+		_, err := service.Authenticate(ctx, "fxk_short")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(repo, nil)
+
+	repo.On("Revoke", mock.Anything, int64(1), int64(3)).Return(true, nil)
+	repo.On("Revoke", mock.Anything, int64(1), int64(4)).Return(false, nil)
+
+	assert.NoError(t, service.Revoke(ctx, 1, 3))
+	assert.ErrorIs(t, service.Revoke(ctx, 1, 4), domain.ErrAPIKeyNotFound)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+const (
+	defaultAuditQueryLimit = 100
+	maxAuditQueryLimit     = 1000
+)
+
+type auditService struct {
+	repo ports.AuditRepository
+	// retention is how long events are kept; zero keeps them forever
+	retention time.Duration
+}
+
+func NewAuditService(repo ports.AuditRepository, retention time.Duration) ports.AuditUseCase {
+	return &auditService{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+func (s *auditService) Record(ctx context.Context, event *domain.AuditEvent) error {
+	return s.repo.Record(ctx, event)
+}
+
+func (s *auditService) Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditQueryLimit
+	}
+	if filter.Limit > maxAuditQueryLimit {
+		filter.Limit = maxAuditQueryLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	events, err := s.repo.Query(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []domain.AuditEvent{}
+	}
+	return events, nil
+}
+
+func (s *auditService) PurgeExpired(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	return s.repo.DeleteBefore(ctx, time.Now().Add(-s.retention))
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditService_Query(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies default limit", func(t *testing.T) {
+		repo := new(MockAuditRepository)
+		service := NewAuditService(repo, 0)
+
+		repo.On("Query", mock.Anything, domain.AuditFilter{Action: domain.AuditActionLogin, Limit: defaultAuditQueryLimit}).Return([]domain.AuditEvent(nil), nil)
+
+		events, err := service.Query(ctx, domain.AuditFilter{Action: domain.AuditActionLogin})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, events)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("caps limit", func(t *testing.T) {
+		repo := new(MockAuditRepository)
+		service := NewAuditService(repo, 0)
+
+		repo.On("Query", mock.Anything, domain.AuditFilter{Limit: maxAuditQueryLimit}).Return([]domain.AuditEvent{{ID: 1}}, nil)
+
+		events, err := service.Query(ctx, domain.AuditFilter{Limit: 50000, Offset: -3})
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAuditService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes events older than retention", func(t *testing.T) {
+		repo := new(MockAuditRepository)
+		service := NewAuditService(repo, 24*time.Hour)
+
+		repo.On("DeleteBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) > 23*time.Hour && time.Since(before) < 25*time.Hour
+		})).Return(int64(4), nil)
+
+		deleted, err := service.PurgeExpired(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), deleted)
+	})
+
+	t.Run("zero retention keeps everything", func(t *testing.T) {
+		repo := new(MockAuditRepository)
+		service := NewAuditService(repo, 0)
+
+		deleted, err := service.PurgeExpired(ctx)
+
+		assert.NoError(t, err)
+		assert.Zero(t, deleted)
+		repo.AssertNotCalled(t, "DeleteBefore", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+// exportDownloadTTL is how long the emailed download link works before the archive is deleted
+const exportDownloadTTL = 48 * time.Hour
+
+// exportBuildTimeout bounds an archive build. A request still pending after it was interrupted,
+// e.g. by a restart, and is marked as failed so the user can ask again.
+const exportBuildTimeout = 30 * time.Minute
+
+// exportFailedRetention is how long a failed export stays visible before it is deleted
+const exportFailedRetention = exportDownloadTTL
+
+const exportFailedMessage = "não foi possível gerar o arquivo, solicite novamente"
+
+// exportedVideo is a videos row as written to the archive, with a link to its frames
+type exportedVideo struct {
+	domain.Video
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+type dataExportService struct {
+	exports ports.DataExportRepository
+	users   ports.UserRepository
+	videos  ports.VideoRepository
+	storage ports.Storage
+	mailer  ports.Mailer
+	baseURL string
+	// run executes the archive build; asynchronous outside of tests
+	run func(task func())
+	// builds tracks the archives being built so shutdown can wait for them
+	builds sync.WaitGroup
+}
+
+func NewDataExportService(exports ports.DataExportRepository, users ports.UserRepository, videos ports.VideoRepository,
+	storage ports.Storage, mailer ports.Mailer, baseURL string) ports.DataExportUseCase {
+	return &dataExportService{
+		exports: exports,
+		users:   users,
+		videos:  videos,
+		storage: storage,
+		mailer:  mailer,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		run:     func(task func()) { go task() },
+	}
+}
+
+func (s *dataExportService) RequestExport(ctx context.Context, userID int64) (*domain.DataExport, error) {
+	pending, err := s.exports.GetPendingByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return pending, nil
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	export := &domain.DataExport{UserID: userID, Status: domain.ExportStatusPending}
+	if err := s.exports.Create(ctx, export); err != nil {
+		return nil, err
+	}
+
+	// The archive is built after the response is sent, so it must outlive the request
+	job := *export
+	buildCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), exportBuildTimeout)
+	s.builds.Add(1)
+	s.run(func() {
+		defer s.builds.Done()
+		defer cancel()
+		s.build(buildCtx, &job, user)
+	})
+	return export, nil
+}
+
+func (s *dataExportService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.builds.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *dataExportService) FailInterrupted(ctx context.Context) error {
+	failed, err := s.exports.FailPendingBefore(ctx, time.Now().Add(-exportBuildTimeout), exportFailedMessage)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		log.Printf("⚠️ %d exportações interrompidas marcadas como falhas", failed)
+	}
+	return nil
+}
+
+func (s *dataExportService) GetExport(ctx context.Context, userID, exportID int64) (*domain.DataExport, error) {
+	export, err := s.exports.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export == nil || export.UserID != userID {
+		return nil, domain.ErrExportNotFound
+	}
+	return export, nil
+}
+
+func (s *dataExportService) OpenDownload(ctx context.Context, token string) (string, error) {
+	export, err := s.exports.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return "", err
+	}
+	if export == nil || export.Status != domain.ExportStatusReady || export.ExpiresAt == nil || time.Now().After(*export.ExpiresAt) {
+		return "", domain.ErrExportNotFound
+	}
+	return s.storage.GetOutputPath(export.FileName), nil
+}
+
+func (s *dataExportService) PurgeExpired(ctx context.Context) error {
+	now := time.Now()
+	expired, err := s.exports.ListExpired(ctx, now, now.Add(-exportFailedRetention))
+	if err != nil {
+		return err
+	}
+	for _, export := range expired {
+		// Failed exports have no archive
+		if export.FileName != "" {
+			if err := s.storage.DeleteFile(ctx, s.storage.GetOutputPath(export.FileName)); err != nil {
+				log.Printf("⚠️ Erro ao remover exportação %d: %v", export.ID, err)
+				continue
+			}
+		}
+		if err := s.exports.Delete(ctx, export.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// build writes the archive and emails the download link, recording a failure on the export
+func (s *dataExportService) build(ctx context.Context, export *domain.DataExport, user *domain.User) {
+	token, err := s.writeArchive(ctx, export, user)
+	if err != nil {
+		log.Printf("❌ Erro ao gerar exportação %d do usuário %d: %v", export.ID, user.ID, err)
+		export.Status = domain.ExportStatusFailed
+		export.Error = exportFailedMessage
+		if err := s.exports.Update(ctx, export); err != nil {
+			log.Printf("❌ Erro ao atualizar exportação %d: %v", export.ID, err)
+		}
+		return
+	}
+
+	link := s.baseURL + "/exports/download?token=" + url.QueryEscape(token)
+	err = s.mailer.Send(domain.Email{
+		To:      user.Email,
+		Subject: "Seus dados estão prontos para download",
+		Body: fmt.Sprintf("Olá, %s!\n\nA cópia dos seus dados pessoais está pronta. Baixe-a pelo link abaixo em até %d horas:\n\n%s\n\nDepois desse prazo o arquivo é excluído.\n",
+			user.Name, int(exportDownloadTTL.Hours()), link),
+	})
+	if err != nil {
+		log.Printf("⚠️ Erro ao enviar e-mail da exportação %d: %v", export.ID, err)
+	}
+}
+
+func (s *dataExportService) writeArchive(ctx context.Context, export *domain.DataExport, user *domain.User) (string, error) {
+	videos, err := s.videos.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+	history, err := s.videos.GetStatusHistoryByUserID(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	profile := *user
+	profile.Password = ""
+
+	exported := make([]exportedVideo, 0, len(videos))
+	for _, video := range videos {
+		item := exportedVideo{Video: video}
+		if video.Status == domain.StatusCompleted && video.ZipPath != "" {
+			item.DownloadURL = fmt.Sprintf("%s/api/videos/%d/download", s.baseURL, video.ID)
+		}
+		exported = append(exported, item)
+	}
+
+	contents := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", profile},
+		{"videos.json", exported},
+		{"status_history.json", history},
+	}
+
+	workDir := fmt.Sprintf("export_%d", export.ID)
+	var files []string
+	defer func() {
+		if len(files) > 0 {
+			s.storage.DeleteDir(ctx, filepath.Dir(files[0]))
+		}
+	}()
+	for _, content := range contents {
+		data, err := json.MarshalIndent(content.data, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		path, err := s.storage.SaveTemp(ctx, filepath.Join(workDir, content.name), bytes.NewReader(data))
+		if err != nil {
+			return "", err
+		}
+		files = append(files, path)
+	}
+
+	// Kept in a subdirectory, out of the public output listing, under an unguessable name
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	fileName := "exports/" + hex.EncodeToString(random) + ".zip"
+	if err := s.storage.SaveZip(ctx, fileName, files); err != nil {
+		return "", err
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	expiresAt := now.Add(exportDownloadTTL)
+	export.Status = domain.ExportStatusReady
+	export.FileName = fileName
+	export.TokenHash = hashToken(token)
+	export.ExpiresAt = &expiresAt
+	export.CompletedAt = &now
+	if err := s.exports.Update(ctx, export); err != nil {
+		return "", err
+	}
+	// An account deleted during the build took the row with it, and the archive must go too
+	current, err := s.exports.GetByID(ctx, export.ID)
+	if err == nil && current == nil {
+		err = domain.ErrExportNotFound
+	}
+	if err != nil {
+		s.storage.DeleteFile(ctx, s.storage.GetOutputPath(fileName))
+		return "", err
+	}
+	return token, nil
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDataExportService(exports *MockDataExportRepository, users *MockUserRepository, videos *MockVideoRepository,
+	storage *MockStorage, mailer *MockMailer) *dataExportService {
+	service := NewDataExportService(exports, users, videos, storage, mailer, "http://localhost:8080/").(*dataExportService)
+	service.run = func(task func()) { task() }
+	return service
+}
+
+func TestDataExportService_RequestExport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("builds archive and emails link", func(t *testing.T) {
+		exports := new(MockDataExportRepository)
+		users := new(MockUserRepository)
+		videos := new(MockVideoRepository)
+		storage := new(MockStorage)
+		mailer := new(MockMailer)
+		service := newTestDataExportService(exports, users, videos, storage, mailer)
+
+		exports.On("GetPendingByUserID", mock.Anything, int64(1)).Return(nil, nil)
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "user@example.com", Name: "User", Password: "hash"}, nil)
+		exports.On("Create", mock.Anything, mock.AnythingOfType("*domain.DataExport")).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.DataExport).ID = 7
+		}).Return(nil)
+		videos.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.Video{{ID: 3, UserID: 1, Status: domain.StatusCompleted, ZipPath: "frames.zip"}}, nil)
+		videos.On("GetStatusHistoryByUserID", mock.Anything, int64(1)).Return([]domain.VideoStatusEvent{{VideoID: 3, Status: domain.StatusCompleted}}, nil)
+		storage.On("SaveTemp", mock.Anything, mock.MatchedBy(func(name string) bool {
+			return strings.HasPrefix(name, "export_7"+string(filepath.Separator))
+		}), mock.Anything).Return(filepath.Join("/tmp", "export_7", "data.json"), nil)
+		storage.On("SaveZip", mock.Anything, mock.MatchedBy(func(name string) bool {
+			return strings.HasPrefix(name, "exports/") && strings.HasSuffix(name, ".zip")
+		}), mock.MatchedBy(func(files []string) bool { return len(files) == 3 })).Return(nil)
+		storage.On("DeleteDir", mock.Anything, filepath.Join("/tmp", "export_7")).Return(nil)
+
+		var tokenHash string
+		exports.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.DataExport) bool {
+			tokenHash = e.TokenHash
+			return e.ID == 7 && e.Status == domain.ExportStatusReady && e.ExpiresAt != nil && e.TokenHash != ""
+		})).Return(nil)
+		exports.On("GetByID", mock.Anything, int64(7)).Return(&domain.DataExport{ID: 7, UserID: 1}, nil)
+		var sent domain.Email
+		mailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(0).(domain.Email)
+		}).Return(nil)
+
+		export, err := service.RequestExport(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ExportStatusPending, export.Status)
+		assert.Equal(t, "user@example.com", sent.To)
+		link := "http://localhost:8080/exports/download?token="
+		assert.Contains(t, sent.Body, link)
+		token := strings.Fields(sent.Body[strings.Index(sent.Body, link)+len(link):])[0]
+		assert.Equal(t, hashToken(token), tokenHash)
+		storage.AssertNumberOfCalls(t, "SaveTemp", 3)
+		exports.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("archive of an account deleted during the build is removed", func(t *testing.T) {
+		exports := new(MockDataExportRepository)
+		users := new(MockUserRepository)
+		videos := new(MockVideoRepository)
+		storage := new(MockStorage)
+		mailer := new(MockMailer)
+		service := newTestDataExportService(exports, users, videos, storage, mailer)
+
+		exports.On("GetPendingByUserID", mock.Anything, int64(1)).Return(nil, nil)
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+		exports.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.DataExport).ID = 7
+		}).Return(nil)
+		videos.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.Video(nil), nil)
+		videos.On("GetStatusHistoryByUserID", mock.Anything, int64(1)).Return([]domain.VideoStatusEvent(nil), nil)
+		storage.On("SaveTemp", mock.Anything, mock.Anything, mock.Anything).Return(filepath.Join("/tmp", "export_7", "data.json"), nil)
+		storage.On("DeleteDir", mock.Anything, mock.Anything).Return(nil)
+		var archive string
+		storage.On("SaveZip", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			archive = args.String(1)
+		}).Return(nil)
+		exports.On("Update", mock.Anything, mock.Anything).Return(nil)
+		// The row cascaded away with the user
+		exports.On("GetByID", mock.Anything, int64(7)).Return(nil, nil)
+		storage.On("GetOutputPath", mock.Anything).Return("/app/outputs/archive.zip")
+		storage.On("DeleteFile", mock.Anything, "/app/outputs/archive.zip").Return(nil)
+
+		_, err := service.RequestExport(ctx, 1)
+
+		assert.NoError(t, err)
+		storage.AssertCalled(t, "GetOutputPath", archive)
+		storage.AssertCalled(t, "DeleteFile", mock.Anything, "/app/outputs/archive.zip")
+		mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("request in progress is reused", func(t *testing.T) {
+		exports := new(MockDataExportRepository)
+		service := newTestDataExportService(exports, nil, nil, nil, nil)
+
+		pending := &domain.DataExport{ID: 7, UserID: 1, Status: domain.ExportStatusPending}
+		exports.On("GetPendingByUserID", mock.Anything, int64(1)).Return(pending, nil)
+
+		export, err := service.RequestExport(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Same(t, pending, export)
+		exports.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("build failure is recorded", func(t *testing.T) {
+		exports := new(MockDataExportRepository)
+		users := new(MockUserRepository)
+		videos := new(MockVideoRepository)
+		mailer := new(MockMailer)
+		service := newTestDataExportService(exports, users, videos, nil, mailer)
+
+		exports.On("GetPendingByUserID", mock.Anything, int64(1)).Return(nil, nil)
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+		exports.On("Create", mock.Anything, mock.Anything).Return(nil)
+		videos.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.Video(nil), errors.New("db down"))
+		exports.On("Update", mock.Anything, mock.MatchedBy(func(e *domain.DataExport) bool {
+			return e.Status == domain.ExportStatusFailed && e.Error != ""
+		})).Return(nil)
+
+		_, err := service.RequestExport(ctx, 1)
+
+		assert.NoError(t, err)
+		exports.AssertExpectations(t)
+		mailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestDataExportService_GetExport(t *testing.T) {
+	ctx := context.Background()
+
+	exports := new(MockDataExportRepository)
+	service := newTestDataExportService(exports, nil, nil, nil, nil)
+
+	exports.On("GetByID", mock.Anything, int64(7)).Return(&domain.DataExport{ID: 7, UserID: 2}, nil)
+
+	export, err := service.GetExport(ctx, 1, 7)
+
+	assert.Nil(t, export)
+	assert.ErrorIs(t, err, domain.ErrExportNotFound)
+}
+
+func TestDataExportService_OpenDownload(t *testing.T) {
+	ctx := context.Background()
+
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("ready", func(t *testing.T) {
+		exports := new(MockDataExportRepository)
+		storage := new(MockStorage)
+		service := newTestDataExportService(exports, nil, nil, storage, nil)
+
+		exports.On("GetByTokenHash", mock.Anything, hashToken("token")).Return(&domain.DataExport{Status: domain.ExportStatusReady, FileName: "exports/a.zip", ExpiresAt: &future}, nil)
+		storage.On("GetOutputPath", "exports/a.zip").Return("/outputs/exports/a.zip")
+
+		path, err := service.OpenDownload(ctx, "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/outputs/exports/a.zip", path)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		exports := new(MockDataExportRepository)
+		service := newTestDataExportService(exports, nil, nil, nil, nil)
+
+		exports.On("GetByTokenHash", mock.Anything, hashToken("token")).Return(&domain.DataExport{Status: domain.ExportStatusReady, FileName: "exports/a.zip", ExpiresAt: &past}, nil)
+
+		_, err := service.OpenDownload(ctx, "token")
+
+		assert.ErrorIs(t, err, domain.ErrExportNotFound)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		exports := new(MockDataExportRepository)
+		service := newTestDataExportService(exports, nil, nil, nil, nil)
+
+		exports.On("GetByTokenHash", mock.Anything, hashToken("token")).Return(nil, nil)
+
+		_, err := service.OpenDownload(ctx, "token")
+
+		assert.ErrorIs(t, err, domain.ErrExportNotFound)
+	})
+}
+
+func TestDataExportService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+
+	exports := new(MockDataExportRepository)
+	storage := new(MockStorage)
+	service := newTestDataExportService(exports, nil, nil, storage, nil)
+
+	exports.On("ListExpired", mock.Anything, mock.AnythingOfType("time.Time"), mock.MatchedBy(func(failedBefore time.Time) bool {
+		return time.Until(failedBefore) < -exportFailedRetention+time.Minute
+	})).Return([]domain.DataExport{
+		{ID: 7, Status: domain.ExportStatusReady, FileName: "exports/a.zip"},
+		{ID: 8, Status: domain.ExportStatusFailed},
+	}, nil)
+	storage.On("GetOutputPath", "exports/a.zip").Return("/outputs/exports/a.zip")
+	storage.On("DeleteFile", mock.Anything, "/outputs/exports/a.zip").Return(nil)
+	exports.On("Delete", mock.Anything, int64(7)).Return(nil)
+	exports.On("Delete", mock.Anything, int64(8)).Return(nil)
+
+	assert.NoError(t, service.PurgeExpired(ctx))
+	exports.AssertExpectations(t)
+	storage.AssertExpectations(t)
+	// A failed export has no archive to remove
+	storage.AssertNumberOfCalls(t, "DeleteFile", 1)
+}
+
+func TestDataExportService_FailInterrupted(t *testing.T) {
+	exports := new(MockDataExportRepository)
+	service := newTestDataExportService(exports, nil, nil, nil, nil)
+
+	exports.On("FailPendingBefore", mock.Anything, mock.MatchedBy(func(createdBefore time.Time) bool {
+		return time.Until(createdBefore) < -exportBuildTimeout+time.Minute
+	}), exportFailedMessage).Return(int64(2), nil)
+
+	assert.NoError(t, service.FailInterrupted(context.Background()))
+	exports.AssertExpectations(t)
+}
+
+func TestDataExportService_Wait(t *testing.T) {
+	exports := new(MockDataExportRepository)
+	users := new(MockUserRepository)
+	videos := new(MockVideoRepository)
+	service := newTestDataExportService(exports, users, videos, nil, nil)
+	var task func()
+	service.run = func(build func()) { task = build }
+
+	exports.On("GetPendingByUserID", mock.Anything, int64(1)).Return(nil, nil)
+	users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+	exports.On("Create", mock.Anything, mock.Anything).Return(nil)
+	videos.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.Video(nil), errors.New("db down"))
+	exports.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.RequestExport(context.Background(), 1)
+	assert.NoError(t, err)
+
+	// The build has not run yet
+	expired, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, service.Wait(expired), context.DeadlineExceeded)
+
+	task()
+	assert.NoError(t, service.Wait(context.Background()))
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+// healthCheckTimeout bounds each probe so one hung dependency cannot stall the readiness endpoint
+const healthCheckTimeout = 2 * time.Second
+
+type healthService struct {
+	checks       map[string]ports.HealthChecker
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewHealthService probes the given dependencies, keyed by the name shown in the report
+func NewHealthService(checks map[string]ports.HealthChecker) ports.HealthUseCase {
+	return &healthService{
+		checks:  checks,
+		timeout: healthCheckTimeout,
+	}
+}
+
+func (s *healthService) Readiness(ctx context.Context) domain.ReadinessReport {
+	report := domain.ReadinessReport{
+		Status: domain.ReadinessReady,
+		Checks: make(map[string]domain.DependencyHealth, len(s.checks)),
+	}
+	// Dependencies are not probed while draining, the answer is already known
+	if s.shuttingDown.Load() {
+		report.Status = domain.ReadinessShuttingDown
+		return report
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, checker := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health := s.probe(ctx, checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = health
+			if health.Status != domain.HealthStatusUp {
+				report.Status = domain.ReadinessNotReady
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (s *healthService) probe(ctx context.Context, checker ports.HealthChecker) domain.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.CheckHealth(ctx)
+	health := domain.DependencyHealth{
+		Status:    domain.HealthStatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		health.Status = domain.HealthStatusDown
+		health.Error = err.Error()
+	}
+	return health
+}
+
+func (s *healthService) BeginShutdown() {
+	s.shuttingDown.Store(true)
+}
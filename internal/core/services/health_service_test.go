@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthService_Readiness(t *testing.T) {
+	t.Run("ready when every dependency is up", func(t *testing.T) {
+		storage := new(MockStorage)
+		publisher := new(MockEventPublisher)
+		service := NewHealthService(map[string]ports.HealthChecker{"storage": storage, "nats": publisher})
+
+		storage.On("CheckHealth", mock.Anything).Return(nil)
+		publisher.On("CheckHealth", mock.Anything).Return(nil)
+
+		report := service.Readiness(context.Background())
+
+		assert.True(t, report.Ready())
+		assert.Equal(t, domain.HealthStatusUp, report.Checks["storage"].Status)
+		assert.Equal(t, domain.HealthStatusUp, report.Checks["nats"].Status)
+		storage.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("not ready when a dependency is down", func(t *testing.T) {
+		storage := new(MockStorage)
+		publisher := new(MockEventPublisher)
+		service := NewHealthService(map[string]ports.HealthChecker{"storage": storage, "nats": publisher})
+
+		storage.On("CheckHealth", mock.Anything).Return(nil)
+		publisher.On("CheckHealth", mock.Anything).Return(errors.New("stream not found"))
+
+		report := service.Readiness(context.Background())
+
+		assert.False(t, report.Ready())
+		assert.Equal(t, domain.ReadinessNotReady, report.Status)
+		assert.Equal(t, domain.HealthStatusUp, report.Checks["storage"].Status)
+		assert.Equal(t, domain.HealthStatusDown, report.Checks["nats"].Status)
+		assert.Equal(t, "stream not found", report.Checks["nats"].Error)
+	})
+
+	t.Run("bounds each probe with a timeout", func(t *testing.T) {
+		service := NewHealthService(map[string]ports.HealthChecker{
+			"database": ports.HealthCheckFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+		}).(*healthService)
+		service.timeout = 10 * time.Millisecond
+
+		report := service.Readiness(context.Background())
+
+		assert.Equal(t, domain.HealthStatusDown, report.Checks["database"].Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"].Error)
+	})
+
+	t.Run("not ready while shutting down", func(t *testing.T) {
+		storage := new(MockStorage)
+		service := NewHealthService(map[string]ports.HealthChecker{"storage": storage})
+
+		service.BeginShutdown()
+		report := service.Readiness(context.Background())
+
+		assert.Equal(t, domain.ReadinessShuttingDown, report.Status)
+		assert.False(t, report.Ready())
+		storage.AssertNotCalled(t, "CheckHealth", mock.Anything)
+	})
+}
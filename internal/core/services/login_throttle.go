@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+const (
+	// loginFailureWindow is how long a failed attempt counts towards delays and lockouts
+	loginFailureWindow = time.Hour
+	// loginDelayAfter is the number of failures after which each new attempt must wait,
+	// doubling from one second up to loginMaxDelay
+	loginDelayAfter = 3
+	loginMaxDelay   = 30 * time.Second
+
+	accountLockoutThreshold = 10
+	ipLockoutThreshold      = 50 // Higher, since several users may share an address
+	loginLockoutDuration    = 15 * time.Minute
+)
+
+type loginSubject struct {
+	scope     string
+	subject   string
+	threshold int
+}
+
+// loginSubjects lists what a login attempt is tracked by. Emails are compared
+// case-insensitively so casing cannot be used to dodge the account limit.
+func loginSubjects(email, clientIP string) []loginSubject {
+	subjects := []loginSubject{{domain.LoginScopeAccount, strings.ToLower(strings.TrimSpace(email)), accountLockoutThreshold}}
+	if clientIP != "" {
+		subjects = append(subjects, loginSubject{domain.LoginScopeIP, clientIP, ipLockoutThreshold})
+	}
+	return subjects
+}
+
+// checkLoginAllowed rejects the attempt while any of its subjects is delayed or locked out
+func (s *userService) checkLoginAllowed(ctx context.Context, email, clientIP string) error {
+	now := time.Now()
+	for _, ls := range loginSubjects(email, clientIP) {
+		throttle, err := s.throttles.Get(ctx, ls.scope, ls.subject)
+		if err != nil {
+			return err
+		}
+		if throttle != nil && throttle.LockedUntil != nil && now.Before(*throttle.LockedUntil) {
+			return &domain.LoginLockedError{RetryAfter: throttle.LockedUntil.Sub(now)}
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure counts the failure and applies the progressive delay or lockout. Errors
+// are only logged: the attempt has already failed either way.
+func (s *userService) recordLoginFailure(ctx context.Context, email, clientIP string) {
+	// Hanging up right after a wrong guess must not keep it from counting
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now()
+	for _, ls := range loginSubjects(email, clientIP) {
+		failures, err := s.throttles.RegisterFailure(ctx, ls.scope, ls.subject, now.Add(-loginFailureWindow))
+		if err != nil {
+			log.Printf("⚠️ Erro ao registrar falha de login (%s): %v", ls.scope, err)
+			continue
+		}
+
+		var wait time.Duration
+		switch {
+		case failures >= ls.threshold:
+			wait = loginLockoutDuration
+			s.auditLockout(ctx, ls, failures, clientIP)
+		case failures >= loginDelayAfter:
+			wait = min(time.Second<<(failures-loginDelayAfter), loginMaxDelay)
+		default:
+			continue
+		}
+
+		if err := s.throttles.Lock(ctx, ls.scope, ls.subject, now.Add(wait)); err != nil {
+			log.Printf("⚠️ Erro ao bloquear tentativas de login (%s): %v", ls.scope, err)
+		}
+	}
+}
+
+// recordLoginSuccess clears the account's failures. The IP counter is kept, otherwise one
+// valid account would let an attacker reset the limit for every other guess.
+func (s *userService) recordLoginSuccess(ctx context.Context, email string) {
+	if err := s.throttles.Reset(ctx, domain.LoginScopeAccount, strings.ToLower(strings.TrimSpace(email))); err != nil {
+		log.Printf("⚠️ Erro ao limpar falhas de login: %v", err)
+	}
+}
+
+func (s *userService) auditLockout(ctx context.Context, ls loginSubject, failures int, clientIP string) {
+	event := &domain.AuditEvent{
+		Action: domain.AuditActionAccountLocked,
+		Target: "email:" + ls.subject,
+		IP:     clientIP,
+		Details: map[string]string{
+			"failures": fmt.Sprint(failures),
+			"until":    time.Now().Add(loginLockoutDuration).UTC().Format(time.RFC3339),
+		},
+	}
+	if ls.scope == domain.LoginScopeIP {
+		event.Action = domain.AuditActionIPLocked
+		event.Target = "ip:" + ls.subject
+	}
+	recordAudit(ctx, s.audit, event)
+}
+
+// recordAudit writes an audit event with the client details of the request in ctx, even if
+// the client has disconnected. A failure is logged but never fails the audited operation.
+func recordAudit(ctx context.Context, audit ports.AuditRepository, event *domain.AuditEvent) {
+	metadata := domain.RequestMetadataFromContext(ctx)
+	if event.IP == "" {
+		event.IP = metadata.IP
+	}
+	event.UserAgent = metadata.UserAgent
+	event.RequestID = metadata.RequestID
+	if err := audit.Record(context.WithoutCancel(ctx), event); err != nil {
+		log.Printf("⚠️ Erro ao registrar evento de auditoria %s: %v", event.Action, err)
+	}
+}
+
+func (s *userService) UnlockAccount(ctx context.Context, actorID, userID int64) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	if err := s.throttles.Reset(ctx, domain.LoginScopeAccount, strings.ToLower(strings.TrimSpace(user.Email))); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.audit, &domain.AuditEvent{
+		Action:  domain.AuditActionAccountUnlocked,
+		ActorID: &actorID,
+		Target:  fmt.Sprintf("user:%d", userID),
+	})
+	return nil
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+const (
+	mfaIssuer         = "Fiap X"
+	recoveryCodeCount = 10
+	mfaTokenType      = "mfa"
+)
+
+type mfaService struct {
+	repo  ports.MFARepository
+	users ports.UserRepository
+	audit ports.AuditRepository
+}
+
+func NewMFAService(repo ports.MFARepository, users ports.UserRepository, audit ports.AuditRepository) ports.MFAUseCase {
+	return &mfaService{
+		repo:  repo,
+		users: users,
+		audit: audit,
+	}
+}
+
+func (s *mfaService) BeginEnrollment(ctx context.Context, userID int64) (domain.MFAEnrollmentResponse, error) {
+	existing, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return domain.MFAEnrollmentResponse{}, err
+	}
+	if existing != nil && existing.ConfirmedAt != nil {
+		return domain.MFAEnrollmentResponse{}, domain.ErrMFAAlreadyEnabled
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return domain.MFAEnrollmentResponse{}, err
+	}
+	if user == nil {
+		return domain.MFAEnrollmentResponse{}, domain.ErrUserNotFound
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return domain.MFAEnrollmentResponse{}, err
+	}
+	if err := s.repo.SavePending(ctx, &domain.UserMFA{UserID: userID, Secret: secret}); err != nil {
+		return domain.MFAEnrollmentResponse{}, err
+	}
+
+	return domain.MFAEnrollmentResponse{
+		Success:    true,
+		Secret:     secret,
+		OTPAuthURI: totpURI(mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+func (s *mfaService) ConfirmEnrollment(ctx context.Context, userID int64, code string) ([]string, error) {
+	mfa, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, domain.ErrMFANotEnrolled
+	}
+	if mfa.ConfirmedAt != nil {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	// Only a TOTP code proves the authenticator app was set up correctly
+	ok, err := verifyMFACode(ctx, s.repo, mfa, code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Confirm(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, &domain.AuditEvent{
+		Action:  domain.AuditActionMFAEnabled,
+		ActorID: &userID,
+		Target:  fmt.Sprintf("user:%d", userID),
+	})
+	return codes, nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, userID int64, code string) error {
+	mfa, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil || mfa.ConfirmedAt == nil {
+		return domain.ErrMFANotEnabled
+	}
+
+	ok, err := verifyMFACode(ctx, s.repo, mfa, code, true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidMFACode
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.audit, &domain.AuditEvent{
+		Action:  domain.AuditActionMFADisabled,
+		ActorID: &userID,
+		Target:  fmt.Sprintf("user:%d", userID),
+	})
+	return nil
+}
+
+// verifyMFACode accepts a TOTP code not used before or, if allowed, an unused recovery code
+func verifyMFACode(ctx context.Context, repo ports.MFARepository, mfa *domain.UserMFA, code string, allowRecovery bool) (bool, error) {
+	if step, ok := verifyTOTP(mfa.Secret, code, time.Now()); ok {
+		return repo.MarkStepUsed(ctx, mfa.UserID, step)
+	}
+	if !allowRecovery {
+		return false, nil
+	}
+	return repo.UseRecoveryCode(ctx, mfa.UserID, hashToken(normalizeRecoveryCode(code)))
+}
+
+// newRecoveryCodes returns codes formatted as "xxxxx-xxxxx" and the hashes to store
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 6)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(totpEncoding.EncodeToString(b))
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashToken(raw))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// currentTOTP returns the code an authenticator app would show right now
+func currentTOTP(t *testing.T, secret string) string {
+	key, err := totpEncoding.DecodeString(secret)
+	assert.NoError(t, err)
+	return totpCode(key, time.Now().Unix()/int64(totpPeriod.Seconds()))
+}
+
+func TestMFAService_BeginEnrollment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		users := new(MockUserRepository)
+		service := NewMFAService(repo, users, nil)
+
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(nil, nil)
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "test@example.com"}, nil)
+		repo.On("SavePending", mock.Anything, mock.AnythingOfType("*domain.UserMFA")).Return(nil)
+
+		resp, err := service.BeginEnrollment(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Secret, 32)
+		assert.True(t, strings.HasPrefix(resp.OTPAuthURI, "otpauth://totp/"))
+		assert.Contains(t, resp.OTPAuthURI, "secret="+resp.Secret)
+		saved := repo.Calls[1].Arguments.Get(1).(*domain.UserMFA)
+		assert.Equal(t, resp.Secret, saved.Secret)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		service := NewMFAService(repo, nil, nil)
+
+		confirmed := time.Now()
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, ConfirmedAt: &confirmed}, nil)
+
+		_, err := service.BeginEnrollment(ctx, 1)
+
+		assert.ErrorIs(t, err, domain.ErrMFAAlreadyEnabled)
+	})
+}
+
+func TestMFAService_ConfirmEnrollment(t *testing.T) {
+	ctx := context.Background()
+
+	secret, _ := newTOTPSecret()
+
+	t.Run("valid code enables MFA with hashed recovery codes", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		audit := new(MockAuditRepository)
+		service := NewMFAService(repo, nil, audit)
+
+		var storedHashes []string
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret}, nil)
+		repo.On("MarkStepUsed", mock.Anything, int64(1), mock.Anything).Return(true, nil)
+		repo.On("Confirm", mock.Anything, int64(1), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			storedHashes = args.Get(2).([]string)
+		})
+		audit.On("Record", mock.Anything, mock.MatchedBy(func(event *domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionMFAEnabled && event.IP == "203.0.113.7" &&
+				event.UserAgent == "test-agent" && event.RequestID == "req-1"
+		})).Return(nil)
+		ctx := domain.ContextWithRequestMetadata(ctx, domain.RequestMetadata{IP: "203.0.113.7", UserAgent: "test-agent", RequestID: "req-1"})
+
+		codes, err := service.ConfirmEnrollment(ctx, 1, currentTOTP(t, secret))
+
+		assert.NoError(t, err)
+		assert.Len(t, codes, recoveryCodeCount)
+		assert.Len(t, storedHashes, recoveryCodeCount)
+		assert.Equal(t, hashToken(normalizeRecoveryCode(strings.ToUpper(codes[0]))), storedHashes[0])
+		assert.NotContains(t, storedHashes, codes[0])
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		service := NewMFAService(repo, nil, nil)
+
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret}, nil)
+
+		_, err := service.ConfirmEnrollment(ctx, 1, "000000x")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		repo.AssertNotCalled(t, "Confirm", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("recovery code is not accepted for confirmation", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		service := NewMFAService(repo, nil, nil)
+
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret}, nil)
+
+		_, err := service.ConfirmEnrollment(ctx, 1, "abcde-fghij")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		repo.AssertNotCalled(t, "UseRecoveryCode", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		service := NewMFAService(repo, nil, nil)
+
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(nil, nil)
+
+		_, err := service.ConfirmEnrollment(ctx, 1, "123456")
+
+		assert.ErrorIs(t, err, domain.ErrMFANotEnrolled)
+	})
+}
+
+func TestMFAService_Disable(t *testing.T) {
+	ctx := context.Background()
+
+	secret, _ := newTOTPSecret()
+	confirmed := time.Now()
+
+	t.Run("with recovery code", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		audit := new(MockAuditRepository)
+		service := NewMFAService(repo, nil, audit)
+
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret, ConfirmedAt: &confirmed}, nil)
+		repo.On("UseRecoveryCode", mock.Anything, int64(1), hashToken("abcdefghij")).Return(true, nil)
+		repo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		audit.On("Record", mock.Anything, mock.AnythingOfType("*domain.AuditEvent")).Return(nil)
+
+		assert.NoError(t, service.Disable(ctx, 1, "ABCDE-FGHIJ"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("replayed TOTP code", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		service := NewMFAService(repo, nil, nil)
+
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret, ConfirmedAt: &confirmed}, nil)
+		repo.On("MarkStepUsed", mock.Anything, int64(1), mock.Anything).Return(false, nil)
+
+		err := service.Disable(ctx, 1, currentTOTP(t, secret))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		repo := new(MockMFARepository)
+		service := NewMFAService(repo, nil, nil)
+
+		repo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret}, nil)
+
+		assert.ErrorIs(t, service.Disable(ctx, 1, "123456"), domain.ErrMFANotEnabled)
+	})
+}
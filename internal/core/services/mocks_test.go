@@ -1,9 +1,12 @@
 package services
 
 import (
+	"context"
 	"io"
+	"time"
 	"video-processor/internal/core/domain"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -11,80 +14,185 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) Create(user *domain.User) error {
-	args := m.Called(user)
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetByEmail(email string) (*domain.User, error) {
-	args := m.Called(email)
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetByID(id int64) (*domain.User, error) {
-	args := m.Called(id)
+func (m *MockUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) List(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 type MockVideoRepository struct {
 	mock.Mock
 }
 
-func (m *MockVideoRepository) Create(video *domain.Video) error {
-	args := m.Called(video)
+func (m *MockVideoRepository) Create(ctx context.Context, video *domain.Video) error {
+	args := m.Called(ctx, video)
 	return args.Error(0)
 }
 
-func (m *MockVideoRepository) Update(video *domain.Video) error {
-	args := m.Called(video)
+func (m *MockVideoRepository) Update(ctx context.Context, video *domain.Video) error {
+	args := m.Called(ctx, video)
 	return args.Error(0)
 }
 
-func (m *MockVideoRepository) GetByID(id int64) (*domain.Video, error) {
-	args := m.Called(id)
+func (m *MockVideoRepository) GetByID(ctx context.Context, id int64) (*domain.Video, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Video), args.Error(1)
 }
 
-func (m *MockVideoRepository) GetByUserID(userID int64) ([]domain.Video, error) {
-	args := m.Called(userID)
+func (m *MockVideoRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) ListAccessibleByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	args := m.Called(ctx, userID)
 	return args.Get(0).([]domain.Video), args.Error(1)
 }
 
+func (m *MockVideoRepository) List(ctx context.Context) ([]domain.Video, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetStatusHistoryByUserID(ctx context.Context, userID int64) ([]domain.VideoStatusEvent, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.VideoStatusEvent), args.Error(1)
+}
+
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *domain.Organization, ownerID int64) error {
+	args := m.Called(ctx, org, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id int64) (*domain.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.Organization, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMember(ctx context.Context, organizationID, userID int64) (*domain.OrganizationMember, error) {
+	args := m.Called(ctx, organizationID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListMembers(ctx context.Context, organizationID int64) ([]domain.OrganizationMember, error) {
+	args := m.Called(ctx, organizationID)
+	return args.Get(0).([]domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) AddMember(ctx context.Context, member *domain.OrganizationMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) error {
+	args := m.Called(ctx, organizationID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	args := m.Called(ctx, organizationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) CountOwners(ctx context.Context, organizationID int64) (int, error) {
+	args := m.Called(ctx, organizationID)
+	return args.Int(0), args.Error(1)
+}
+
 type MockStorage struct {
 	mock.Mock
 }
 
-func (m *MockStorage) SaveUpload(filename string, data io.Reader) (string, error) {
-	args := m.Called(filename, data)
+func (m *MockStorage) CheckHealth(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockStorage) SaveUpload(ctx context.Context, filename string, data io.Reader) (string, error) {
+	args := m.Called(ctx, filename, data)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockStorage) SaveZip(zipFilename string, files []string) error {
-	args := m.Called(zipFilename, files)
+func (m *MockStorage) SaveTemp(ctx context.Context, filename string, data io.Reader) (string, error) {
+	args := m.Called(ctx, filename, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorage) SaveZip(ctx context.Context, zipFilename string, files []string) error {
+	args := m.Called(ctx, zipFilename, files)
 	return args.Error(0)
 }
 
-func (m *MockStorage) DeleteFile(path string) error {
-	args := m.Called(path)
+func (m *MockStorage) DeleteFile(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
 	return args.Error(0)
 }
 
-func (m *MockStorage) DeleteDir(path string) error {
-	args := m.Called(path)
+func (m *MockStorage) DeleteDir(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
 	return args.Error(0)
 }
 
-func (m *MockStorage) ListOutputs() ([]domain.FileInfo, error) {
-	args := m.Called()
+func (m *MockStorage) ListOutputs(ctx context.Context) ([]domain.FileInfo, error) {
+	args := m.Called(ctx)
 	return args.Get(0).([]domain.FileInfo), args.Error(1)
 }
 
@@ -102,7 +210,383 @@ type MockEventPublisher struct {
 	mock.Mock
 }
 
-func (m *MockEventPublisher) PublishUploadEvent(videoID int64, filename string) error {
-	args := m.Called(videoID, filename)
+func (m *MockEventPublisher) PublishUploadEvent(ctx context.Context, videoID int64, filename string) error {
+	args := m.Called(ctx, videoID, filename)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) CheckHealth(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// MockUnitOfWork runs fn directly; the expectation's error stands in for a failed commit
+type MockUnitOfWork struct {
+	mock.Mock
+}
+
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) MarkRotated(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	args := m.Called(ctx, familyID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID int64, keepFamilyID string) error {
+	args := m.Called(ctx, userID, keepFamilyID)
+	return args.Error(0)
+}
+
+type MockAccountTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountTokenRepository) Create(ctx context.Context, token *domain.AccountToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAccountTokenRepository) Consume(ctx context.Context, tokenHash, purpose string) (*domain.AccountToken, error) {
+	args := m.Called(ctx, tokenHash, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AccountToken), args.Error(1)
+}
+
+func (m *MockAccountTokenRepository) InvalidateForUser(ctx context.Context, userID int64, purpose string) error {
+	args := m.Called(ctx, userID, purpose)
+	return args.Error(0)
+}
+
+type MockLoginThrottleRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginThrottleRepository) Get(ctx context.Context, scope, subject string) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, scope, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) RegisterFailure(ctx context.Context, scope, subject string, resetBefore time.Time) (int, error) {
+	args := m.Called(ctx, scope, subject, resetBefore)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) Lock(ctx context.Context, scope, subject string, until time.Time) error {
+	args := m.Called(ctx, scope, subject, until)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottleRepository) Reset(ctx context.Context, scope, subject string) error {
+	args := m.Called(ctx, scope, subject)
+	return args.Error(0)
+}
+
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) GetByUserID(ctx context.Context, userID int64) (*domain.UserMFA, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserMFA), args.Error(1)
+}
+
+func (m *MockMFARepository) SavePending(ctx context.Context, mfa *domain.UserMFA) error {
+	args := m.Called(ctx, mfa)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) Confirm(ctx context.Context, userID int64, recoveryCodeHashes []string) error {
+	args := m.Called(ctx, userID, recoveryCodeHashes)
 	return args.Error(0)
 }
+
+func (m *MockMFARepository) MarkStepUsed(ctx context.Context, userID, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) Delete(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Record(ctx context.Context, event *domain.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) Query(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockDataExportRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) GetByID(ctx context.Context, id int64) (*domain.DataExport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) GetPendingByUserID(ctx context.Context, userID int64) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DataExport, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) ListExpired(ctx context.Context, expiredBefore, failedBefore time.Time) ([]domain.DataExport, error) {
+	args := m.Called(ctx, expiredBefore, failedBefore)
+	return args.Get(0).([]domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) FailPendingBefore(ctx context.Context, createdBefore time.Time, reason string) (int64, error) {
+	args := m.Called(ctx, createdBefore, reason)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDataExportRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockAccountUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAccountUseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	args := m.Called(ctx, token, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) SendVerificationEmail(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) IsEmailVerified(ctx context.Context, userID int64) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(email domain.Email) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByUserID(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, userID, keyID int64) (bool, error) {
+	args := m.Called(ctx, userID, keyID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, keyID int64) error {
+	args := m.Called(ctx, keyID)
+	return args.Error(0)
+}
+
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) GetBySubject(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error) {
+	args := m.Called(ctx, issuer, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+}
+
+type MockOIDCStateRepository struct {
+	mock.Mock
+}
+
+func (m *MockOIDCStateRepository) Create(ctx context.Context, state *domain.OIDCLoginState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+func (m *MockOIDCStateRepository) Consume(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	args := m.Called(ctx, stateHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OIDCLoginState), args.Error(1)
+}
+
+func (m *MockOIDCStateRepository) DeleteExpired(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockIdentityProvider struct {
+	mock.Mock
+}
+
+func (m *MockIdentityProvider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	args := m.Called(state, nonce, codeVerifier)
+	return args.String(0)
+}
+
+func (m *MockIdentityProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalIdentity, error) {
+	args := m.Called(ctx, code, codeVerifier, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExternalIdentity), args.Error(1)
+}
+
+// testSigner is a real HS256 signer, so token round-trips are exercised end to end
+type testSigner struct {
+	secret []byte
+}
+
+func newTestSigner(secret string) *testSigner {
+	return &testSigner{secret: []byte(secret)}
+}
+
+func (s *testSigner) Sign(claims map[string]interface{}) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(claims)).SignedString(s.secret)
+}
+
+func (s *testSigner) Verify(token string) (map[string]interface{}, error) {
+	parsed, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{"HS256"}))
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Claims.(jwt.MapClaims), nil
+}
+
+func (s *testSigner) PublicKeys() domain.JWKS {
+	return domain.JWKS{}
+}
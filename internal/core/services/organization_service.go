@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+// maxOrganizationNameLength matches the size of organizations.name
+const maxOrganizationNameLength = 255
+
+type organizationService struct {
+	orgs  ports.OrganizationRepository
+	users ports.UserRepository
+	uow   ports.UnitOfWork
+}
+
+func NewOrganizationService(orgs ports.OrganizationRepository, users ports.UserRepository, uow ports.UnitOfWork) ports.OrganizationUseCase {
+	return &organizationService{
+		orgs:  orgs,
+		users: users,
+		uow:   uow,
+	}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, userID int64, name string) (*domain.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxOrganizationNameLength {
+		return nil, domain.ErrInvalidOrgName
+	}
+
+	org := &domain.Organization{Name: name, Role: domain.OrgRoleOwner}
+	if err := s.orgs.Create(ctx, org, userID); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (s *organizationService) ListOrganizations(ctx context.Context, userID int64) ([]domain.Organization, error) {
+	return s.orgs.ListByUserID(ctx, userID)
+}
+
+func (s *organizationService) ListMembers(ctx context.Context, userID, organizationID int64) ([]domain.OrganizationMember, error) {
+	if _, err := requireOrgRole(ctx, s.orgs, organizationID, userID, domain.OrgRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.orgs.ListMembers(ctx, organizationID)
+}
+
+func (s *organizationService) AddMember(ctx context.Context, actorID, organizationID int64, email, role string) (*domain.OrganizationMember, error) {
+	if !domain.IsValidOrgRole(role) {
+		return nil, domain.ErrInvalidOrgRole
+	}
+	if _, err := requireOrgRole(ctx, s.orgs, organizationID, actorID, domain.OrgRoleOwner); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	member := &domain.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Email:          user.Email,
+		Name:           user.Name,
+		Role:           role,
+	}
+	if err := s.orgs.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *organizationService) UpdateMemberRole(ctx context.Context, actorID, organizationID, userID int64, role string) error {
+	if !domain.IsValidOrgRole(role) {
+		return domain.ErrInvalidOrgRole
+	}
+	if _, err := requireOrgRole(ctx, s.orgs, organizationID, actorID, domain.OrgRoleOwner); err != nil {
+		return err
+	}
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		member, owners, err := s.lockMember(ctx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == domain.OrgRoleOwner && role != domain.OrgRoleOwner && owners <= 1 {
+			return domain.ErrLastOwner
+		}
+		return s.orgs.UpdateMemberRole(ctx, organizationID, userID, role)
+	})
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, actorID, organizationID, userID int64) error {
+	// Any member may leave; removing someone else takes an owner
+	required := domain.OrgRoleOwner
+	if actorID == userID {
+		required = domain.OrgRoleViewer
+	}
+	if _, err := requireOrgRole(ctx, s.orgs, organizationID, actorID, required); err != nil {
+		return err
+	}
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		member, owners, err := s.lockMember(ctx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == domain.OrgRoleOwner && owners <= 1 {
+			return domain.ErrLastOwner
+		}
+		return s.orgs.RemoveMember(ctx, organizationID, userID)
+	})
+}
+
+// lockMember returns the member and the number of owners. The owners stay locked until the
+// unit of work running in ctx ends, so two owners demoting or removing each other cannot
+// both see the other one still counted.
+func (s *organizationService) lockMember(ctx context.Context, organizationID, userID int64) (*domain.OrganizationMember, int, error) {
+	owners, err := s.orgs.CountOwners(ctx, organizationID)
+	if err != nil {
+		return nil, 0, err
+	}
+	member, err := s.orgs.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if member == nil {
+		return nil, 0, domain.ErrMemberNotFound
+	}
+	return member, owners, nil
+}
+
+// requireOrgRole returns the user's membership if it grants at least the required role.
+// Organizations the user does not belong to are reported as missing.
+func requireOrgRole(ctx context.Context, orgs ports.OrganizationRepository, organizationID, userID int64, required string) (*domain.OrganizationMember, error) {
+	member, err := orgs.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	if !domain.OrgRoleAllows(member.Role, required) {
+		return nil, domain.ErrOrgPermissionDenied
+	}
+	return member, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ownerOf(orgID, userID int64) *domain.OrganizationMember {
+	return &domain.OrganizationMember{OrganizationID: orgID, UserID: userID, Role: domain.OrgRoleOwner}
+}
+
+func TestOrganizationService_CreateOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creator becomes owner", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		service := NewOrganizationService(orgs, nil, nil)
+
+		orgs.On("Create", mock.Anything, mock.MatchedBy(func(o *domain.Organization) bool { return o.Name == "Edição" }), int64(1)).Return(nil)
+
+		org, err := service.CreateOrganization(ctx, 1, "  Edição ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OrgRoleOwner, org.Role)
+		orgs.AssertExpectations(t)
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		service := NewOrganizationService(nil, nil, nil)
+
+		_, err := service.CreateOrganization(ctx, 1, "   ")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOrgName)
+	})
+}
+
+func TestOrganizationService_AddMember(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("owner adds member", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		users := new(MockUserRepository)
+		service := NewOrganizationService(orgs, users, nil)
+
+		orgs.On("GetMember", mock.Anything, int64(3), int64(1)).Return(ownerOf(3, 1), nil)
+		users.On("GetByEmail", mock.Anything, "editor@example.com").Return(&domain.User{ID: 2, Email: "editor@example.com"}, nil)
+		orgs.On("AddMember", mock.Anything, mock.MatchedBy(func(m *domain.OrganizationMember) bool {
+			return m.OrganizationID == 3 && m.UserID == 2 && m.Role == domain.OrgRoleEditor
+		})).Return(nil)
+
+		member, err := service.AddMember(ctx, 1, 3, "editor@example.com", domain.OrgRoleEditor)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), member.UserID)
+		orgs.AssertExpectations(t)
+	})
+
+	t.Run("editor cannot add members", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		service := NewOrganizationService(orgs, nil, nil)
+
+		orgs.On("GetMember", mock.Anything, int64(3), int64(1)).Return(&domain.OrganizationMember{Role: domain.OrgRoleEditor}, nil)
+
+		_, err := service.AddMember(ctx, 1, 3, "someone@example.com", domain.OrgRoleViewer)
+
+		assert.ErrorIs(t, err, domain.ErrOrgPermissionDenied)
+	})
+
+	t.Run("non member sees no organization", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		service := NewOrganizationService(orgs, nil, nil)
+
+		orgs.On("GetMember", mock.Anything, int64(3), int64(1)).Return(nil, nil)
+
+		_, err := service.AddMember(ctx, 1, 3, "someone@example.com", domain.OrgRoleViewer)
+
+		assert.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		service := NewOrganizationService(nil, nil, nil)
+
+		_, err := service.AddMember(ctx, 1, 3, "someone@example.com", "admin")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOrgRole)
+	})
+}
+
+func TestOrganizationService_UpdateMemberRole(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("last owner cannot be demoted", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		service := NewOrganizationService(orgs, nil, new(MockUnitOfWork))
+
+		orgs.On("GetMember", mock.Anything, int64(3), int64(1)).Return(ownerOf(3, 1), nil)
+		orgs.On("CountOwners", mock.Anything, int64(3)).Return(1, nil)
+
+		err := service.UpdateMemberRole(ctx, 1, 3, 1, domain.OrgRoleViewer)
+
+		assert.ErrorIs(t, err, domain.ErrLastOwner)
+		orgs.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("promote member", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		uow := new(MockUnitOfWork)
+		service := NewOrganizationService(orgs, nil, uow)
+
+		orgs.On("GetMember", mock.Anything, int64(3), int64(1)).Return(ownerOf(3, 1), nil)
+		orgs.On("CountOwners", mock.Anything, int64(3)).Return(1, nil)
+		orgs.On("GetMember", mock.Anything, int64(3), int64(2)).Return(&domain.OrganizationMember{OrganizationID: 3, UserID: 2, Role: domain.OrgRoleViewer}, nil)
+		orgs.On("UpdateMemberRole", mock.Anything, int64(3), int64(2), domain.OrgRoleEditor).Return(nil)
+		uow.On("Do", mock.Anything).Return(nil)
+
+		assert.NoError(t, service.UpdateMemberRole(ctx, 1, 3, 2, domain.OrgRoleEditor))
+		orgs.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("owners are locked before the member is read", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		uow := new(MockUnitOfWork)
+		service := NewOrganizationService(orgs, nil, uow)
+
+		var calls []string
+		orgs.On("GetMember", mock.Anything, int64(3), int64(1)).Return(ownerOf(3, 1), nil).Once()
+		orgs.On("CountOwners", mock.Anything, int64(3)).Return(2, nil).Run(func(mock.Arguments) { calls = append(calls, "CountOwners") })
+		orgs.On("GetMember", mock.Anything, int64(3), int64(2)).Return(ownerOf(3, 2), nil).Run(func(mock.Arguments) { calls = append(calls, "GetMember") })
+		orgs.On("UpdateMemberRole", mock.Anything, int64(3), int64(2), domain.OrgRoleViewer).Return(nil).Run(func(mock.Arguments) { calls = append(calls, "UpdateMemberRole") })
+		uow.On("Do", mock.Anything).Return(nil)
+
+		assert.NoError(t, service.UpdateMemberRole(ctx, 1, 3, 2, domain.OrgRoleViewer))
+		assert.Equal(t, []string{"CountOwners", "GetMember", "UpdateMemberRole"}, calls)
+		uow.AssertExpectations(t)
+	})
+}
+
+func TestOrganizationService_RemoveMember(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("viewer leaves", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		uow := new(MockUnitOfWork)
+		service := NewOrganizationService(orgs, nil, uow)
+
+		viewer := &domain.OrganizationMember{OrganizationID: 3, UserID: 2, Role: domain.OrgRoleViewer}
+		orgs.On("GetMember", mock.Anything, int64(3), int64(2)).Return(viewer, nil)
+		orgs.On("CountOwners", mock.Anything, int64(3)).Return(1, nil)
+		orgs.On("RemoveMember", mock.Anything, int64(3), int64(2)).Return(nil)
+		uow.On("Do", mock.Anything).Return(nil)
+
+		assert.NoError(t, service.RemoveMember(ctx, 2, 3, 2))
+		orgs.AssertExpectations(t)
+	})
+
+	t.Run("viewer cannot remove others", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		service := NewOrganizationService(orgs, nil, nil)
+
+		orgs.On("GetMember", mock.Anything, int64(3), int64(2)).Return(&domain.OrganizationMember{Role: domain.OrgRoleViewer}, nil)
+
+		err := service.RemoveMember(ctx, 2, 3, 1)
+
+		assert.ErrorIs(t, err, domain.ErrOrgPermissionDenied)
+	})
+
+	t.Run("last owner cannot leave", func(t *testing.T) {
+		orgs := new(MockOrganizationRepository)
+		service := NewOrganizationService(orgs, nil, new(MockUnitOfWork))
+
+		orgs.On("GetMember", mock.Anything, int64(3), int64(1)).Return(ownerOf(3, 1), nil)
+		orgs.On("CountOwners", mock.Anything, int64(3)).Return(1, nil)
+
+		err := service.RemoveMember(ctx, 1, 3, 1)
+
+		assert.ErrorIs(t, err, domain.ErrLastOwner)
+	})
+}
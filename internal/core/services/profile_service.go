@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// reauthWindow is how recent a login must be to stand in for the password of an SSO account
+const reauthWindow = 5 * time.Minute
+
+type profileService struct {
+	users    ports.UserRepository
+	videos   ports.VideoRepository
+	exports  ports.DataExportRepository
+	storage  ports.Storage
+	sessions ports.RefreshTokenRepository
+	accounts ports.AccountUseCase
+	audit    ports.AuditRepository
+	uow      ports.UnitOfWork
+}
+
+func NewProfileService(users ports.UserRepository, videos ports.VideoRepository, exports ports.DataExportRepository, storage ports.Storage,
+	sessions ports.RefreshTokenRepository, accounts ports.AccountUseCase, audit ports.AuditRepository, uow ports.UnitOfWork) ports.ProfileUseCase {
+	return &profileService{
+		users:    users,
+		videos:   videos,
+		exports:  exports,
+		storage:  storage,
+		sessions: sessions,
+		accounts: accounts,
+		audit:    audit,
+		uow:      uow,
+	}
+}
+
+func (s *profileService) GetProfile(ctx context.Context, userID int64) (*domain.User, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	user.Password = ""
+	return user, nil
+}
+
+func (s *profileService) UpdateProfile(ctx context.Context, userID int64, req domain.UpdateProfileRequest) (*domain.User, error) {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, domain.ErrInvalidName
+		}
+		user.Name = name
+	}
+
+	emailChanged := false
+	if req.Email != nil && !strings.EqualFold(*req.Email, user.Email) {
+		existing, err := s.users.GetByEmail(ctx, *req.Email)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, domain.ErrEmailTaken
+		}
+		user.Email = *req.Email
+		user.EmailVerified = false
+		emailChanged = true
+	}
+
+	if err := s.users.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if emailChanged {
+		// The change is saved either way; the link can be resent from /api/me/verify-email
+		if err := s.accounts.SendVerificationEmail(ctx, user.ID); err != nil {
+			log.Printf("⚠️ Erro ao enviar e-mail de verificação para o usuário %d: %v", user.ID, err)
+		}
+	}
+	return user, nil
+}
+
+func (s *profileService) ChangePassword(ctx context.Context, userID int64, sessionID, currentPassword, newPassword string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	// SSO-only accounts have no password to confirm and must use the reset flow instead
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)) != nil {
+		return domain.ErrInvalidPassword
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.users.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
+		return err
+	}
+	return s.sessions.RevokeAllForUser(ctx, userID, sessionID)
+}
+
+func (s *profileService) DeleteAccount(ctx context.Context, userID int64, authenticatedAt time.Time, password string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+	if user.Password == "" {
+		// Without a password, only a fresh login at the identity provider proves who is asking
+		if time.Since(authenticatedAt) > reauthWindow {
+			return domain.ErrReauthenticationRequired
+		}
+	} else if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return domain.ErrInvalidPassword
+	}
+
+	var videos []domain.Video
+	var exports []domain.DataExport
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		uploaded, err := s.videos.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		// Videos uploaded to an organization outlive the account, files included
+		videos = nil
+		for _, video := range uploaded {
+			if video.OrganizationID == nil {
+				videos = append(videos, video)
+			}
+		}
+		// Export rows cascade away with the user, after which PurgeExpired cannot find their archives.
+		// Listing in the transaction locks them, so a build finishing now cannot leave one behind.
+		if exports, err = s.exports.ListByUserID(ctx, userID); err != nil {
+			return err
+		}
+		// Deleting the user locks its row, so uploads racing with the deletion wait and then fail
+		return s.users.Delete(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The rows are gone once committed, so a failed rollback can no longer leave an account
+	// pointing at missing files. Files that cannot be removed are logged for an operator.
+	filesLeft := s.deleteFiles(context.WithoutCancel(ctx), videos, exports)
+
+	recordAudit(ctx, s.audit, &domain.AuditEvent{
+		Action:  domain.AuditActionAccountDeleted,
+		ActorID: &userID,
+		Target:  fmt.Sprintf("user:%d", userID),
+		Details: map[string]string{"videos": fmt.Sprint(len(videos)), "files_left": fmt.Sprint(filesLeft)},
+	})
+	return nil
+}
+
+// deleteFiles removes the uploads and frame archives of videos and the data export archives,
+// returning how many files could not be removed
+func (s *profileService) deleteFiles(ctx context.Context, videos []domain.Video, exports []domain.DataExport) int {
+	var paths []string
+	for _, video := range videos {
+		paths = append(paths, s.storage.GetUploadPath(video.StorageKey))
+		if video.ZipPath != "" {
+			paths = append(paths, s.storage.GetOutputPath(filepath.Base(video.ZipPath)))
+		}
+	}
+	for _, export := range exports {
+		if export.FileName != "" {
+			paths = append(paths, s.storage.GetOutputPath(export.FileName))
+		}
+	}
+
+	failed := 0
+	for _, path := range paths {
+		if err := s.storage.DeleteFile(ctx, path); err != nil {
+			log.Printf("⚠️ Erro ao remover o arquivo %s de uma conta excluída: %v", path, err)
+			failed++
+		}
+	}
+	return failed
+}
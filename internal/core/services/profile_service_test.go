@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestProfileService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new email requires verification", func(t *testing.T) {
+		users := new(MockUserRepository)
+		accounts := new(MockAccountUseCase)
+		service := NewProfileService(users, nil, nil, nil, nil, accounts, nil, nil)
+
+		newEmail := "new@example.com"
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "old@example.com", Name: "Old", Password: "hash", EmailVerified: true}, nil)
+		users.On("GetByEmail", mock.Anything, newEmail).Return(nil, nil)
+		users.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Email == newEmail && !u.EmailVerified && u.Name == "Old"
+		})).Return(nil)
+		accounts.On("SendVerificationEmail", mock.Anything, int64(1)).Return(nil)
+
+		user, err := service.UpdateProfile(ctx, 1, domain.UpdateProfileRequest{Email: &newEmail})
+
+		assert.NoError(t, err)
+		assert.Equal(t, newEmail, user.Email)
+		assert.Empty(t, user.Password)
+		users.AssertExpectations(t)
+		accounts.AssertExpectations(t)
+	})
+
+	t.Run("name only keeps verification", func(t *testing.T) {
+		users := new(MockUserRepository)
+		accounts := new(MockAccountUseCase)
+		service := NewProfileService(users, nil, nil, nil, nil, accounts, nil, nil)
+
+		name := "  New Name "
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "user@example.com", EmailVerified: true}, nil)
+		users.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Name == "New Name" && u.EmailVerified
+		})).Return(nil)
+
+		_, err := service.UpdateProfile(ctx, 1, domain.UpdateProfileRequest{Name: &name})
+
+		assert.NoError(t, err)
+		accounts.AssertNotCalled(t, "SendVerificationEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("email already taken", func(t *testing.T) {
+		users := new(MockUserRepository)
+		service := NewProfileService(users, nil, nil, nil, nil, nil, nil, nil)
+
+		taken := "taken@example.com"
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "user@example.com"}, nil)
+		users.On("GetByEmail", mock.Anything, taken).Return(&domain.User{ID: 2}, nil)
+
+		_, err := service.UpdateProfile(ctx, 1, domain.UpdateProfileRequest{Email: &taken})
+
+		assert.ErrorIs(t, err, domain.ErrEmailTaken)
+		users.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		users := new(MockUserRepository)
+		service := NewProfileService(users, nil, nil, nil, nil, nil, nil, nil)
+
+		blank := "   "
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+
+		_, err := service.UpdateProfile(ctx, 1, domain.UpdateProfileRequest{Name: &blank})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidName)
+	})
+}
+
+func TestProfileService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("current"), bcrypt.MinCost)
+
+	t.Run("success keeps current session", func(t *testing.T) {
+		users := new(MockUserRepository)
+		sessions := new(MockRefreshTokenRepository)
+		service := NewProfileService(users, nil, nil, nil, sessions, nil, nil, nil)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Password: string(hash)}, nil)
+		users.On("UpdatePassword", mock.Anything, int64(1), mock.MatchedBy(func(h string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(h), []byte("new-password")) == nil
+		})).Return(nil)
+		sessions.On("RevokeAllForUser", mock.Anything, int64(1), "session-1").Return(nil)
+
+		err := service.ChangePassword(ctx, 1, "session-1", "current", "new-password")
+
+		assert.NoError(t, err)
+		users.AssertExpectations(t)
+		sessions.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		users := new(MockUserRepository)
+		service := NewProfileService(users, nil, nil, nil, nil, nil, nil, nil)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Password: string(hash)}, nil)
+
+		err := service.ChangePassword(ctx, 1, "session-1", "wrong", "new-password")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+		users.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProfileService_DeleteAccount(t *testing.T) {
+	ctx := context.Background()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+
+	t.Run("deletes the user then the files of its personal videos", func(t *testing.T) {
+		orgID := int64(3)
+		users := new(MockUserRepository)
+		videos := new(MockVideoRepository)
+		storage := new(MockStorage)
+		audit := new(MockAuditRepository)
+		uow := new(MockUnitOfWork)
+		exports := new(MockDataExportRepository)
+		service := NewProfileService(users, videos, exports, storage, nil, nil, audit, uow)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Password: string(hash)}, nil)
+		videos.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.Video{
+			{ID: 10, StorageKey: "a.mp4", ZipPath: "/app/outputs/a.zip"},
+			{ID: 11, StorageKey: "b.mp4"},
+			// Uploaded to an organization, which keeps it
+			{ID: 12, OrganizationID: &orgID, StorageKey: "c.mp4", ZipPath: "/app/outputs/c.zip"},
+		}, nil)
+		storage.On("GetUploadPath", "a.mp4").Return("/app/uploads/a.mp4")
+		storage.On("GetUploadPath", "b.mp4").Return("/app/uploads/b.mp4")
+		storage.On("GetOutputPath", "a.zip").Return("/app/outputs/a.zip")
+		// A ready archive and one still being built, which has no file yet
+		exports.On("ListByUserID", mock.Anything, int64(1)).Return([]domain.DataExport{
+			{ID: 5, FileName: "exports/e.zip", Status: domain.ExportStatusReady},
+			{ID: 6, Status: domain.ExportStatusPending},
+		}, nil)
+		storage.On("GetOutputPath", "exports/e.zip").Return("/app/outputs/exports/e.zip")
+		storage.On("DeleteFile", mock.Anything, mock.Anything).Return(nil)
+		users.On("Delete", mock.Anything, int64(1)).Return(nil)
+		uow.On("Do", mock.Anything).Return(nil)
+		audit.On("Record", mock.Anything, mock.MatchedBy(func(event *domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionAccountDeleted && event.Details["videos"] == "2" &&
+				event.IP == "203.0.113.7" && event.UserAgent == "test-agent" && event.RequestID == "req-1"
+		})).Return(nil)
+		ctx := domain.ContextWithRequestMetadata(ctx, domain.RequestMetadata{IP: "203.0.113.7", UserAgent: "test-agent", RequestID: "req-1"})
+
+		err := service.DeleteAccount(ctx, 1, time.Time{}, "password")
+
+		assert.NoError(t, err)
+		storage.AssertNumberOfCalls(t, "DeleteFile", 4)
+		storage.AssertCalled(t, "DeleteFile", mock.Anything, "/app/outputs/exports/e.zip")
+		storage.AssertNotCalled(t, "GetUploadPath", "c.mp4")
+		storage.AssertNotCalled(t, "DeleteFile", mock.Anything, "/app/outputs/c.zip")
+		users.AssertExpectations(t)
+		uow.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("storage failure after the commit keeps the deletion", func(t *testing.T) {
+		users := new(MockUserRepository)
+		videos := new(MockVideoRepository)
+		storage := new(MockStorage)
+		audit := new(MockAuditRepository)
+		uow := new(MockUnitOfWork)
+		exports := new(MockDataExportRepository)
+		service := NewProfileService(users, videos, exports, storage, nil, nil, audit, uow)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+		videos.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.Video{{ID: 10, StorageKey: "a.mp4"}, {ID: 11, StorageKey: "b.mp4"}}, nil)
+		exports.On("ListByUserID", mock.Anything, int64(1)).Return([]domain.DataExport(nil), nil)
+		users.On("Delete", mock.Anything, int64(1)).Return(nil)
+		uow.On("Do", mock.Anything).Return(nil)
+		storage.On("GetUploadPath", "a.mp4").Return("/app/uploads/a.mp4")
+		storage.On("GetUploadPath", "b.mp4").Return("/app/uploads/b.mp4")
+		storage.On("DeleteFile", mock.Anything, "/app/uploads/a.mp4").Return(errors.New("permission denied"))
+		storage.On("DeleteFile", mock.Anything, "/app/uploads/b.mp4").Return(nil)
+		audit.On("Record", mock.Anything, mock.MatchedBy(func(event *domain.AuditEvent) bool {
+			return event.Details["files_left"] == "1"
+		})).Return(nil)
+
+		err := service.DeleteAccount(ctx, 1, time.Now(), "")
+
+		assert.NoError(t, err)
+		// The second file is still removed after the first one failed
+		storage.AssertCalled(t, "DeleteFile", mock.Anything, "/app/uploads/b.mp4")
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("commit failure keeps the files and is not audited", func(t *testing.T) {
+		users := new(MockUserRepository)
+		videos := new(MockVideoRepository)
+		storage := new(MockStorage)
+		audit := new(MockAuditRepository)
+		uow := new(MockUnitOfWork)
+		exports := new(MockDataExportRepository)
+		service := NewProfileService(users, videos, exports, storage, nil, nil, audit, uow)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+		videos.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.Video{{ID: 10, StorageKey: "a.mp4"}}, nil)
+		exports.On("ListByUserID", mock.Anything, int64(1)).Return([]domain.DataExport{{ID: 5, FileName: "exports/e.zip"}}, nil)
+		users.On("Delete", mock.Anything, int64(1)).Return(nil)
+		uow.On("Do", mock.Anything).Return(errors.New("connection reset"))
+
+		err := service.DeleteAccount(ctx, 1, time.Now(), "")
+
+		assert.EqualError(t, err, "connection reset")
+		storage.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+		audit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		users := new(MockUserRepository)
+		videos := new(MockVideoRepository)
+		service := NewProfileService(users, videos, nil, nil, nil, nil, nil, nil)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Password: string(hash)}, nil)
+
+		err := service.DeleteAccount(ctx, 1, time.Now(), "wrong")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+		videos.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything)
+	})
+	t.Run("account without password needs a recent login", func(t *testing.T) {
+		users := new(MockUserRepository)
+		uow := new(MockUnitOfWork)
+		service := NewProfileService(users, nil, nil, nil, nil, nil, nil, uow)
+
+		users.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1}, nil)
+
+		err := service.DeleteAccount(ctx, 1, time.Now().Add(-reauthWindow-time.Minute), "")
+
+		assert.ErrorIs(t, err, domain.ErrReauthenticationRequired)
+		uow.AssertNotCalled(t, "Do", mock.Anything)
+	})
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
+)
+
+// loginStateTTL bounds how long a user may take at the IdP login page
+const loginStateTTL = 10 * time.Minute
+
+type ssoService struct {
+	provider ports.IdentityProvider
+	states   ports.OIDCStateRepository
+	users    ports.UserUseCase
+}
+
+func NewSSOService(provider ports.IdentityProvider, states ports.OIDCStateRepository, users ports.UserUseCase) ports.SSOUseCase {
+	return &ssoService{
+		provider: provider,
+		states:   states,
+		users:    users,
+	}
+}
+
+func (s *ssoService) BeginLogin(ctx context.Context) (string, string, error) {
+	if err := s.states.DeleteExpired(ctx); err != nil {
+		log.Printf("⚠️ Erro ao limpar estados de login SSO expirados: %v", err)
+	}
+
+	state, err := randomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomToken(16)
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := randomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.states.Create(ctx, &domain.OIDCLoginState{
+		StateHash:    hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(loginStateTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return s.provider.AuthCodeURL(state, nonce, codeVerifier), state, nil
+}
+
+func (s *ssoService) CompleteLogin(ctx context.Context, state, code string) (domain.AuthResponse, error) {
+	pending, err := s.states.Consume(ctx, hashToken(state))
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if pending == nil || time.Now().After(pending.ExpiresAt) {
+		return domain.AuthResponse{}, domain.ErrInvalidLoginState
+	}
+
+	identity, err := s.provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	return s.users.LoginWithIdentity(ctx, *identity)
+}
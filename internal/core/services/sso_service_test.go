@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSSOService_BeginLogin(t *testing.T) {
+	ctx := context.Background()
+
+	provider := new(MockIdentityProvider)
+	states := new(MockOIDCStateRepository)
+	service := NewSSOService(provider, states, nil)
+
+	var stored *domain.OIDCLoginState
+	states.On("DeleteExpired", mock.Anything).Return(nil)
+	states.On("Create", mock.Anything, mock.AnythingOfType("*domain.OIDCLoginState")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.OIDCLoginState)
+	})
+	provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything).Return("https://idp.example.com/authorize?x=1")
+
+	authURL, state, err := service.BeginLogin(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/authorize?x=1", authURL)
+	assert.Equal(t, hashToken(state), stored.StateHash)
+	assert.NotEmpty(t, stored.Nonce)
+	assert.GreaterOrEqual(t, len(stored.CodeVerifier), 43) // RFC 7636 minimum
+	provider.AssertCalled(t, "AuthCodeURL", state, stored.Nonce, stored.CodeVerifier)
+}
+
+func TestSSOService_CompleteLogin(t *testing.T) {
+	ctx := context.Background()
+
+	identity := &domain.ExternalIdentity{Issuer: "https://idp.example.com", Subject: "abc", Email: "staff@example.com", EmailVerified: true, Name: "Staff"}
+
+	t.Run("links existing user by verified email", func(t *testing.T) {
+		provider := new(MockIdentityProvider)
+		states := new(MockOIDCStateRepository)
+		repo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		identities := new(MockUserIdentityRepository)
+		users := NewUserService(repo, tokenRepo, identities, nil, nil, nil, newTestSigner("test-secret"))
+		service := NewSSOService(provider, states, users)
+
+		pending := &domain.OIDCLoginState{Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: time.Now().Add(time.Minute)}
+		states.On("Consume", mock.Anything, hashToken("state")).Return(pending, nil)
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(identity, nil)
+		identities.On("GetBySubject", mock.Anything, identity.Issuer, identity.Subject).Return(nil, nil)
+		repo.On("GetByEmail", mock.Anything, identity.Email).Return(&domain.User{ID: 9, Email: identity.Email, Password: "hash", Role: domain.RoleUser}, nil)
+		identities.On("Create", mock.Anything, mock.MatchedBy(func(i *domain.UserIdentity) bool {
+			return i.UserID == 9 && i.Subject == "abc"
+		})).Return(nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		resp, err := service.CompleteLogin(ctx, "state", "code")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), resp.User.ID)
+		assert.Empty(t, resp.User.Password)
+		assert.NotEmpty(t, resp.Token)
+		identities.AssertExpectations(t)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("provisions new user", func(t *testing.T) {
+		provider := new(MockIdentityProvider)
+		states := new(MockOIDCStateRepository)
+		repo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		identities := new(MockUserIdentityRepository)
+		users := NewUserService(repo, tokenRepo, identities, nil, nil, nil, newTestSigner("test-secret"))
+		service := NewSSOService(provider, states, users)
+
+		pending := &domain.OIDCLoginState{Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: time.Now().Add(time.Minute)}
+		states.On("Consume", mock.Anything, hashToken("state")).Return(pending, nil)
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(identity, nil)
+		identities.On("GetBySubject", mock.Anything, identity.Issuer, identity.Subject).Return(nil, nil)
+		repo.On("GetByEmail", mock.Anything, identity.Email).Return(nil, nil)
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Email == identity.Email && u.Name == "Staff" && u.Password == "" && u.Role == domain.RoleUser
+		})).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.User).ID = 10
+		})
+		identities.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserIdentity")).Return(nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		resp, err := service.CompleteLogin(ctx, "state", "code")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), resp.User.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("already linked identity", func(t *testing.T) {
+		provider := new(MockIdentityProvider)
+		states := new(MockOIDCStateRepository)
+		repo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		identities := new(MockUserIdentityRepository)
+		users := NewUserService(repo, tokenRepo, identities, nil, nil, nil, newTestSigner("test-secret"))
+		service := NewSSOService(provider, states, users)
+
+		pending := &domain.OIDCLoginState{Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: time.Now().Add(time.Minute)}
+		states.On("Consume", mock.Anything, hashToken("state")).Return(pending, nil)
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(identity, nil)
+		identities.On("GetBySubject", mock.Anything, identity.Issuer, identity.Subject).Return(&domain.UserIdentity{UserID: 9}, nil)
+		repo.On("GetByID", mock.Anything, int64(9)).Return(&domain.User{ID: 9, Email: "renamed@example.com"}, nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		resp, err := service.CompleteLogin(ctx, "state", "code")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), resp.User.ID)
+		identities.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unverified email is rejected", func(t *testing.T) {
+		provider := new(MockIdentityProvider)
+		states := new(MockOIDCStateRepository)
+		identities := new(MockUserIdentityRepository)
+		users := NewUserService(nil, nil, identities, nil, nil, nil, newTestSigner("test-secret"))
+		service := NewSSOService(provider, states, users)
+
+		unverified := *identity
+		unverified.EmailVerified = false
+		pending := &domain.OIDCLoginState{Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: time.Now().Add(time.Minute)}
+		states.On("Consume", mock.Anything, hashToken("state")).Return(pending, nil)
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(&unverified, nil)
+		identities.On("GetBySubject", mock.Anything, identity.Issuer, identity.Subject).Return(nil, nil)
+
+		_, err := service.CompleteLogin(ctx, "state", "code")
+
+		assert.ErrorIs(t, err, domain.ErrExternalEmailUnverified)
+	})
+
+	t.Run("unknown or expired state", func(t *testing.T) {
+		provider := new(MockIdentityProvider)
+		states := new(MockOIDCStateRepository)
+		service := NewSSOService(provider, states, nil)
+
+		states.On("Consume", mock.Anything, hashToken("unknown")).Return(nil, nil)
+		states.On("Consume", mock.Anything, hashToken("expired")).Return(&domain.OIDCLoginState{ExpiresAt: time.Now().Add(-time.Second)}, nil)
+
+		_, err := service.CompleteLogin(ctx, "unknown", "code")
+		assert.ErrorIs(t, err, domain.ErrInvalidLoginState)
+
+		_, err = service.CompleteLogin(ctx, "expired", "code")
+		assert.ErrorIs(t, err, domain.ErrInvalidLoginState)
+
+		provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		provider := new(MockIdentityProvider)
+		states := new(MockOIDCStateRepository)
+		service := NewSSOService(provider, states, nil)
+
+		pending := &domain.OIDCLoginState{Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: time.Now().Add(time.Minute)}
+		states.On("Consume", mock.Anything, hashToken("state")).Return(pending, nil)
+		provider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(nil, errors.New("nonce mismatch"))
+
+		_, err := service.CompleteLogin(ctx, "state", "code")
+
+		assert.EqualError(t, err, "nonce mismatch")
+	})
+}
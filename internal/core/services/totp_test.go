@@ -0,0 +1,49 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1 vectors truncated to 6 digits
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range vectors {
+		step, ok := verifyTOTP(secret, want, time.Unix(unix, 0))
+		assert.True(t, ok, "time %d", unix)
+		assert.Equal(t, unix/30, step)
+	}
+
+	t.Run("adjacent step is accepted, older is not", func(t *testing.T) {
+		now := time.Unix(1234567890, 0)
+		_, ok := verifyTOTP(secret, "005924", now.Add(totpPeriod))
+		assert.True(t, ok)
+		_, ok = verifyTOTP(secret, "005924", now.Add(3*totpPeriod))
+		assert.False(t, ok)
+	})
+
+	t.Run("malformed input", func(t *testing.T) {
+		_, ok := verifyTOTP(secret, "12345", time.Now())
+		assert.False(t, ok)
+		_, ok = verifyTOTP("not base32!", "123456", time.Now())
+		assert.False(t, ok)
+	})
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := totpURI("Fiap X", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Fiap%20X:user@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Fiap+X")
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"time"
+	"video-processor/internal/core/domain"
+)
+
+// mfaChallenge answers the password step of a login for an account with MFA enabled
+func (s *userService) mfaChallenge(user *domain.User) (domain.AuthResponse, error) {
+	now := time.Now()
+	token, err := s.signer.Sign(map[string]interface{}{
+		"sub": user.ID,
+		"typ": mfaTokenType,
+		"iat": now.Unix(),
+		"exp": now.Add(domain.MFAChallengeTTL).Unix(),
+	})
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	return domain.AuthResponse{
+		User:        *user,
+		MFARequired: true,
+		MFAToken:    token,
+	}, nil
+}
+
+// VerifyMFA completes a login started with Login. Wrong codes count as failed logins,
+// so the code step is throttled just like the password step.
+func (s *userService) VerifyMFA(ctx context.Context, mfaToken, code, clientIP string) (domain.AuthResponse, error) {
+	claims, err := s.signer.Verify(mfaToken)
+	if err != nil {
+		return domain.AuthResponse{}, domain.ErrInvalidToken
+	}
+	if typ, _ := claims["typ"].(string); typ != mfaTokenType {
+		return domain.AuthResponse{}, domain.ErrInvalidToken
+	}
+	userID, ok := claims["sub"].(float64)
+	if !ok {
+		return domain.AuthResponse{}, domain.ErrInvalidToken
+	}
+
+	user, err := s.repo.GetByID(ctx, int64(userID))
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if user == nil {
+		return domain.AuthResponse{}, domain.ErrInvalidToken
+	}
+
+	if err := s.checkLoginAllowed(ctx, user.Email, clientIP); err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	mfa, err := s.mfa.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if mfa == nil || mfa.ConfirmedAt == nil {
+		// Disabled since the challenge was issued
+		return domain.AuthResponse{}, domain.ErrInvalidToken
+	}
+
+	valid, err := verifyMFACode(ctx, s.mfa, mfa, code, true)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if !valid {
+		s.recordLoginFailure(ctx, user.Email, clientIP)
+		return domain.AuthResponse{}, domain.ErrInvalidMFACode
+	}
+
+	s.recordLoginSuccess(ctx, user.Email)
+	user.Password = ""
+	return s.startSession(ctx, user)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUserService_LoginWithMFA(t *testing.T) {
+	ctx := context.Background()
+
+	secret, _ := newTOTPSecret()
+	confirmed := time.Now()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	newService := func() (*userService, *MockUserRepository, *MockRefreshTokenRepository, *MockMFARepository, *MockLoginThrottleRepository) {
+		repo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		mfaRepo := new(MockMFARepository)
+		throttles := new(MockLoginThrottleRepository)
+		service := NewUserService(repo, tokenRepo, nil, mfaRepo, throttles, nil, newTestSigner("test-secret")).(*userService)
+
+		repo.On("GetByEmail", mock.Anything, "test@example.com").Return(&domain.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}, nil)
+		repo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}, nil)
+		mfaRepo.On("GetByUserID", mock.Anything, int64(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret, ConfirmedAt: &confirmed}, nil)
+		throttles.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		return service, repo, tokenRepo, mfaRepo, throttles
+	}
+
+	t.Run("password step returns a challenge, not tokens", func(t *testing.T) {
+		service, _, tokenRepo, _, throttles := newService()
+
+		resp, err := service.Login(ctx, "test@example.com", "password123", "")
+
+		assert.NoError(t, err)
+		assert.True(t, resp.MFARequired)
+		assert.NotEmpty(t, resp.MFAToken)
+		assert.Empty(t, resp.Token)
+		assert.Empty(t, resp.RefreshToken)
+		assert.Empty(t, resp.User.Password)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		throttles.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything, mock.Anything)
+
+		_, err = service.ValidateToken(ctx, resp.MFAToken)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken, "challenge token must not work as an access token")
+	})
+
+	t.Run("valid code issues tokens", func(t *testing.T) {
+		service, _, tokenRepo, mfaRepo, throttles := newService()
+		mfaRepo.On("MarkStepUsed", mock.Anything, int64(1), mock.Anything).Return(true, nil)
+		throttles.On("Reset", mock.Anything, domain.LoginScopeAccount, "test@example.com").Return(nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		challenge, _ := service.mfaChallenge(&domain.User{ID: 1})
+		resp, err := service.VerifyMFA(ctx, challenge.MFAToken, currentTOTP(t, secret), "203.0.113.7")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.Token)
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.Empty(t, resp.User.Password)
+		throttles.AssertExpectations(t)
+	})
+
+	t.Run("wrong code counts as a failed login", func(t *testing.T) {
+		service, _, _, mfaRepo, throttles := newService()
+		mfaRepo.On("UseRecoveryCode", mock.Anything, int64(1), mock.Anything).Return(false, nil)
+		throttles.On("RegisterFailure", mock.Anything, domain.LoginScopeAccount, "test@example.com", mock.Anything).Return(1, nil)
+
+		challenge, _ := service.mfaChallenge(&domain.User{ID: 1})
+		_, err := service.VerifyMFA(ctx, challenge.MFAToken, "wrong", "")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		throttles.AssertExpectations(t)
+	})
+
+	t.Run("access token is not a challenge token", func(t *testing.T) {
+		service, _, tokenRepo, _, _ := newService()
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		session, _ := service.issueTokens(ctx, &domain.User{ID: 1}, "family-1", time.Now())
+		_, err := service.VerifyMFA(ctx, session.Token, currentTOTP(t, secret), "")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
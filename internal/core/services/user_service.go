@@ -1,35 +1,50 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"time"
 	"video-processor/internal/core/domain"
 	"video-processor/internal/core/ports"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const refreshTokenTTL = 30 * 24 * time.Hour
+
 type userService struct {
-	repo      ports.UserRepository
-	jwtSecret string
+	repo       ports.UserRepository
+	tokenRepo  ports.RefreshTokenRepository
+	identities ports.UserIdentityRepository
+	mfa        ports.MFARepository
+	throttles  ports.LoginThrottleRepository
+	audit      ports.AuditRepository
+	signer     ports.TokenSigner
+	accessTTL  time.Duration
+	refreshTTL time.Duration
 }
 
-func NewUserService(repo ports.UserRepository, jwtSecret string) ports.UserUseCase {
-	if jwtSecret == "" {
-		jwtSecret = "fiapx-secret-key" // Default secret for dev
-	}
+func NewUserService(repo ports.UserRepository, tokenRepo ports.RefreshTokenRepository, identities ports.UserIdentityRepository,
+	mfa ports.MFARepository, throttles ports.LoginThrottleRepository, audit ports.AuditRepository, signer ports.TokenSigner) ports.UserUseCase {
 	return &userService{
-		repo:      repo,
-		jwtSecret: jwtSecret,
+		repo:       repo,
+		tokenRepo:  tokenRepo,
+		identities: identities,
+		mfa:        mfa,
+		throttles:  throttles,
+		audit:      audit,
+		signer:     signer,
+		accessTTL:  domain.AccessTokenTTL,
+		refreshTTL: refreshTokenTTL,
 	}
 }
 
-func (s *userService) Register(email, password, name string) (domain.AuthResponse, error) {
+func (s *userService) Register(ctx context.Context, email, password, name string) (domain.AuthResponse, error) {
 	// Check if user already exists
-	existingUser, _ := s.repo.GetByEmail(email)
+	existingUser, _ := s.repo.GetByEmail(ctx, email)
 	if existingUser != nil {
-		return domain.AuthResponse{}, errors.New("usuário já cadastrado com este e-mail")
+		return domain.AuthResponse{}, domain.ErrEmailTaken
 	}
 
 	// Hash password
@@ -42,15 +57,10 @@ func (s *userService) Register(email, password, name string) (domain.AuthRespons
 		Email:    email,
 		Password: string(hashedPassword),
 		Name:     name,
+		Role:     domain.RoleUser,
 	}
 
-	err = s.repo.Create(user)
-	if err != nil {
-		return domain.AuthResponse{}, err
-	}
-
-	// Generate token
-	token, err := s.generateToken(user)
+	err = s.repo.Create(ctx, user)
 	if err != nil {
 		return domain.AuthResponse{}, err
 	}
@@ -58,43 +68,116 @@ func (s *userService) Register(email, password, name string) (domain.AuthRespons
 	// Hide password in response
 	user.Password = ""
 
-	return domain.AuthResponse{
-		User:  *user,
-		Token: token,
-	}, nil
+	return s.startSession(ctx, user)
 }
 
-func (s *userService) Login(email, password string) (domain.AuthResponse, error) {
-	user, err := s.repo.GetByEmail(email)
+func (s *userService) Login(ctx context.Context, email, password, clientIP string) (domain.AuthResponse, error) {
+	if err := s.checkLoginAllowed(ctx, email, clientIP); err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
 	if err != nil || user == nil {
+		s.recordLoginFailure(ctx, email, clientIP)
 		return domain.AuthResponse{}, errors.New("credenciais inválidas")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
+		s.recordLoginFailure(ctx, email, clientIP)
 		return domain.AuthResponse{}, errors.New("credenciais inválidas")
 	}
 
-	token, err := s.generateToken(user)
+	user.Password = ""
+
+	mfa, err := s.mfa.GetByUserID(ctx, user.ID)
 	if err != nil {
 		return domain.AuthResponse{}, err
 	}
+	if mfa != nil && mfa.ConfirmedAt != nil {
+		// Failures are only cleared once the second factor is verified too
+		return s.mfaChallenge(user)
+	}
 
-	user.Password = ""
+	s.recordLoginSuccess(ctx, email)
+
+	return s.startSession(ctx, user)
+}
+
+func (s *userService) ListUsers(ctx context.Context) ([]domain.User, error) {
+	users, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		users[i].Password = ""
+	}
+	return users, nil
+}
 
-	return domain.AuthResponse{
-		User:  *user,
-		Token: token,
-	}, nil
+func (s *userService) LoginWithIdentity(ctx context.Context, identity domain.ExternalIdentity) (domain.AuthResponse, error) {
+	linked, err := s.identities.GetBySubject(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if linked != nil {
+		user, err := s.repo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return domain.AuthResponse{}, err
+		}
+		if user == nil {
+			return domain.AuthResponse{}, errors.New("usuário vinculado não encontrado")
+		}
+		user.Password = ""
+		return s.startSession(ctx, user)
+	}
+
+	// Only a verified email is trusted to link or create an account
+	if !identity.EmailVerified || identity.Email == "" {
+		return domain.AuthResponse{}, domain.ErrExternalEmailUnverified
+	}
+
+	user, err := s.repo.GetByEmail(ctx, identity.Email)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if user == nil {
+		user, err = s.provisionUser(ctx, identity)
+		if err != nil {
+			return domain.AuthResponse{}, err
+		}
+	}
+
+	err = s.identities.Create(ctx, &domain.UserIdentity{
+		UserID:  user.ID,
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Email:   identity.Email,
+	})
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	user.Password = ""
+	return s.startSession(ctx, user)
 }
 
-func (s *userService) generateToken(user *domain.User) (string, error) {
-	claims := jwt.MapClaims{
-		"sub":   user.ID,
-		"email": user.Email,
-		"exp":   time.Now().Add(time.Hour * 24).Unix(),
+// provisionUser creates an SSO-only account. Its empty password hash never matches,
+// so password login stays disabled until the user sets a password.
+func (s *userService) provisionUser(ctx context.Context, identity domain.ExternalIdentity) (*domain.User, error) {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	user := &domain.User{
+		Email:         identity.Email,
+		Name:          name,
+		Role:          domain.RoleUser,
+		EmailVerified: true, // Only verified provider emails reach this point
+	}
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 	"video-processor/internal/core/domain"
 
 	"github.com/stretchr/testify/assert"
@@ -11,37 +13,44 @@ import (
 )
 
 func TestUserService_Register(t *testing.T) {
+	ctx := context.Background()
+
 	jwtSecret := "test-secret"
 
 	t.Run("success", func(t *testing.T) {
 		repo := new(MockUserRepository)
-		service := NewUserService(repo, jwtSecret)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(repo, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
 
 		email := "test@example.com"
 		password := "password123"
 		name := "Test User"
 
-		repo.On("GetByEmail", email).Return(nil, nil)
-		repo.On("Create", mock.AnythingOfType("*domain.User")).Return(nil)
+		repo.On("GetByEmail", mock.Anything, email).Return(nil, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
 
-		resp, err := service.Register(email, password, name)
+		resp, err := service.Register(ctx, email, password, name)
 
 		assert.NoError(t, err)
 		assert.Equal(t, email, resp.User.Email)
 		assert.Equal(t, name, resp.User.Name)
+		assert.Equal(t, domain.RoleUser, resp.User.Role)
 		assert.NotEmpty(t, resp.Token)
+		assert.NotEmpty(t, resp.RefreshToken)
 		assert.Empty(t, resp.User.Password)
 		repo.AssertExpectations(t)
 	})
 
 	t.Run("user already exists", func(t *testing.T) {
 		repo := new(MockUserRepository)
-		service := NewUserService(repo, jwtSecret)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(repo, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
 
 		email := "existing@example.com"
-		repo.On("GetByEmail", email).Return(&domain.User{Email: email}, nil)
+		repo.On("GetByEmail", mock.Anything, email).Return(&domain.User{Email: email}, nil)
 
-		resp, err := service.Register(email, "pass", "Name")
+		resp, err := service.Register(ctx, email, "pass", "Name")
 
 		assert.Error(t, err)
 		assert.Equal(t, "usuário já cadastrado com este e-mail", err.Error())
@@ -51,13 +60,14 @@ func TestUserService_Register(t *testing.T) {
 
 	t.Run("repo create error", func(t *testing.T) {
 		repo := new(MockUserRepository)
-		service := NewUserService(repo, jwtSecret)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(repo, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
 
 		email := "test@example.com"
-		repo.On("GetByEmail", email).Return(nil, nil)
-		repo.On("Create", mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
+		repo.On("GetByEmail", mock.Anything, email).Return(nil, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
 
-		_, err := service.Register(email, "password", "Name")
+		_, err := service.Register(ctx, email, "password", "Name")
 
 		assert.Error(t, err)
 		assert.Equal(t, "db error", err.Error())
@@ -66,11 +76,16 @@ func TestUserService_Register(t *testing.T) {
 }
 
 func TestUserService_Login(t *testing.T) {
+	ctx := context.Background()
+
 	jwtSecret := "test-secret"
 
 	t.Run("success", func(t *testing.T) {
 		repo := new(MockUserRepository)
-		service := NewUserService(repo, jwtSecret)
+		tokenRepo := new(MockRefreshTokenRepository)
+		throttles := new(MockLoginThrottleRepository)
+		mfaRepo := new(MockMFARepository)
+		service := NewUserService(repo, tokenRepo, nil, mfaRepo, throttles, nil, newTestSigner(jwtSecret))
 
 		email := "test@example.com"
 		password := "password123"
@@ -83,20 +98,28 @@ func TestUserService_Login(t *testing.T) {
 			Name:     "Test User",
 		}
 
-		repo.On("GetByEmail", email).Return(user, nil)
+		throttles.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		throttles.On("Reset", mock.Anything, domain.LoginScopeAccount, email).Return(nil)
+		mfaRepo.On("GetByUserID", mock.Anything, int64(1)).Return(nil, nil)
+		repo.On("GetByEmail", mock.Anything, email).Return(user, nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
 
-		resp, err := service.Login(email, password)
+		resp, err := service.Login(ctx, email, password, "203.0.113.7")
 
 		assert.NoError(t, err)
 		assert.Equal(t, email, resp.User.Email)
 		assert.NotEmpty(t, resp.Token)
+		assert.NotEmpty(t, resp.RefreshToken)
 		assert.Empty(t, resp.User.Password)
 		repo.AssertExpectations(t)
+		throttles.AssertExpectations(t)
 	})
 
 	t.Run("invalid credentials - wrong password", func(t *testing.T) {
 		repo := new(MockUserRepository)
-		service := NewUserService(repo, jwtSecret)
+		tokenRepo := new(MockRefreshTokenRepository)
+		throttles := new(MockLoginThrottleRepository)
+		service := NewUserService(repo, tokenRepo, nil, nil, throttles, nil, newTestSigner(jwtSecret))
 
 		email := "test@example.com"
 		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.DefaultCost)
@@ -106,26 +129,187 @@ func TestUserService_Login(t *testing.T) {
 			Password: string(hashedPassword),
 		}
 
-		repo.On("GetByEmail", email).Return(user, nil)
+		throttles.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		throttles.On("RegisterFailure", mock.Anything, domain.LoginScopeAccount, email, mock.Anything).Return(1, nil)
+		throttles.On("RegisterFailure", mock.Anything, domain.LoginScopeIP, "203.0.113.7", mock.Anything).Return(1, nil)
+		repo.On("GetByEmail", mock.Anything, email).Return(user, nil)
 
-		_, err := service.Login(email, "wrong-password")
+		_, err := service.Login(ctx, email, "wrong-password", "203.0.113.7")
 
 		assert.Error(t, err)
 		assert.Equal(t, "credenciais inválidas", err.Error())
 		repo.AssertExpectations(t)
+		throttles.AssertExpectations(t)
+		throttles.AssertNotCalled(t, "Lock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("invalid credentials - user not found", func(t *testing.T) {
 		repo := new(MockUserRepository)
-		service := NewUserService(repo, jwtSecret)
+		tokenRepo := new(MockRefreshTokenRepository)
+		throttles := new(MockLoginThrottleRepository)
+		service := NewUserService(repo, tokenRepo, nil, nil, throttles, nil, newTestSigner(jwtSecret))
 
 		email := "nonexistent@example.com"
-		repo.On("GetByEmail", email).Return(nil, nil)
+		throttles.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		throttles.On("RegisterFailure", mock.Anything, domain.LoginScopeAccount, email, mock.Anything).Return(1, nil)
+		repo.On("GetByEmail", mock.Anything, email).Return(nil, nil)
 
-		_, err := service.Login(email, "password")
+		_, err := service.Login(ctx, email, "password", "")
 
 		assert.Error(t, err)
 		assert.Equal(t, "credenciais inválidas", err.Error())
 		repo.AssertExpectations(t)
+		throttles.AssertExpectations(t)
+	})
+}
+
+func TestUserService_LoginThrottling(t *testing.T) {
+	ctx := context.Background()
+
+	jwtSecret := "test-secret"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+
+	t.Run("locked account rejects even the right password", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		throttles := new(MockLoginThrottleRepository)
+		service := NewUserService(repo, nil, nil, nil, throttles, nil, newTestSigner(jwtSecret))
+
+		until := time.Now().Add(5 * time.Minute)
+		throttles.On("Get", mock.Anything, domain.LoginScopeAccount, "test@example.com").Return(&domain.LoginThrottle{LockedUntil: &until}, nil)
+
+		_, err := service.Login(ctx, "Test@Example.com", "correct-password", "203.0.113.7")
+
+		var locked *domain.LoginLockedError
+		assert.ErrorAs(t, err, &locked)
+		assert.InDelta(t, 300, locked.Seconds(), 1)
+		repo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
 	})
+
+	t.Run("locked IP", func(t *testing.T) {
+		throttles := new(MockLoginThrottleRepository)
+		service := NewUserService(nil, nil, nil, nil, throttles, nil, newTestSigner(jwtSecret))
+
+		until := time.Now().Add(time.Minute)
+		throttles.On("Get", mock.Anything, domain.LoginScopeAccount, mock.Anything).Return(nil, nil)
+		throttles.On("Get", mock.Anything, domain.LoginScopeIP, "203.0.113.7").Return(&domain.LoginThrottle{LockedUntil: &until}, nil)
+
+		_, err := service.Login(ctx, "other@example.com", "password", "203.0.113.7")
+
+		var locked *domain.LoginLockedError
+		assert.ErrorAs(t, err, &locked)
+	})
+
+	t.Run("expired lock allows the attempt", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		throttles := new(MockLoginThrottleRepository)
+		mfaRepo := new(MockMFARepository)
+		service := NewUserService(repo, tokenRepo, nil, mfaRepo, throttles, nil, newTestSigner(jwtSecret))
+
+		past := time.Now().Add(-time.Second)
+		throttles.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&domain.LoginThrottle{LockedUntil: &past}, nil)
+		throttles.On("Reset", mock.Anything, domain.LoginScopeAccount, "test@example.com").Return(nil)
+		mfaRepo.On("GetByUserID", mock.Anything, int64(1)).Return(nil, nil)
+		repo.On("GetByEmail", mock.Anything, "test@example.com").Return(&domain.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}, nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		_, err := service.Login(ctx, "test@example.com", "correct-password", "203.0.113.7")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("progressive delay", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		throttles := new(MockLoginThrottleRepository)
+		service := NewUserService(repo, nil, nil, nil, throttles, nil, newTestSigner(jwtSecret))
+
+		throttles.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		throttles.On("RegisterFailure", mock.Anything, domain.LoginScopeAccount, "test@example.com", mock.Anything).Return(loginDelayAfter+2, nil)
+		throttles.On("Lock", mock.Anything, domain.LoginScopeAccount, "test@example.com", mock.MatchedBy(func(until time.Time) bool {
+			wait := time.Until(until)
+			return wait > 3*time.Second && wait <= 4*time.Second
+		})).Return(nil)
+		repo.On("GetByEmail", mock.Anything, "test@example.com").Return(nil, nil)
+
+		_, err := service.Login(ctx, "test@example.com", "wrong", "")
+
+		assert.Error(t, err)
+		throttles.AssertExpectations(t)
+	})
+
+	t.Run("threshold locks out and audits", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		throttles := new(MockLoginThrottleRepository)
+		audit := new(MockAuditRepository)
+		service := NewUserService(repo, nil, nil, nil, throttles, audit, newTestSigner(jwtSecret))
+
+		throttles.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		throttles.On("RegisterFailure", mock.Anything, domain.LoginScopeAccount, "test@example.com", mock.Anything).Return(accountLockoutThreshold, nil)
+		throttles.On("RegisterFailure", mock.Anything, domain.LoginScopeIP, "203.0.113.7", mock.Anything).Return(1, nil)
+		throttles.On("Lock", mock.Anything, domain.LoginScopeAccount, "test@example.com", mock.MatchedBy(func(until time.Time) bool {
+			return time.Until(until) > loginLockoutDuration-time.Minute
+		})).Return(nil)
+		audit.On("Record", mock.Anything, mock.MatchedBy(func(event *domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionAccountLocked && event.Target == "email:test@example.com" && event.IP == "203.0.113.7" &&
+				event.UserAgent == "test-agent" && event.RequestID == "req-1"
+		})).Return(nil)
+		repo.On("GetByEmail", mock.Anything, "test@example.com").Return(&domain.User{Email: "test@example.com", Password: string(hashedPassword)}, nil)
+		ctx := domain.ContextWithRequestMetadata(ctx, domain.RequestMetadata{IP: "203.0.113.7", UserAgent: "test-agent", RequestID: "req-1"})
+
+		_, err := service.Login(ctx, "test@example.com", "wrong", "203.0.113.7")
+
+		assert.Error(t, err)
+		throttles.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+}
+
+func TestUserService_UnlockAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		throttles := new(MockLoginThrottleRepository)
+		audit := new(MockAuditRepository)
+		service := NewUserService(repo, nil, nil, nil, throttles, audit, newTestSigner("test-secret"))
+
+		repo.On("GetByID", mock.Anything, int64(5)).Return(&domain.User{ID: 5, Email: "Locked@Example.com"}, nil)
+		throttles.On("Reset", mock.Anything, domain.LoginScopeAccount, "locked@example.com").Return(nil)
+		audit.On("Record", mock.Anything, mock.MatchedBy(func(event *domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionAccountUnlocked && *event.ActorID == 1 && event.Target == "user:5"
+		})).Return(nil)
+
+		assert.NoError(t, service.UnlockAccount(ctx, 1, 5))
+		throttles.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		service := NewUserService(repo, nil, nil, nil, nil, nil, newTestSigner("test-secret"))
+
+		repo.On("GetByID", mock.Anything, int64(5)).Return(nil, nil)
+
+		assert.ErrorIs(t, service.UnlockAccount(ctx, 1, 5), domain.ErrUserNotFound)
+	})
+}
+
+func TestUserService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(MockUserRepository)
+	service := NewUserService(repo, nil, nil, nil, nil, nil, newTestSigner("test-secret"))
+
+	repo.On("List", mock.Anything).Return([]domain.User{
+		{ID: 1, Email: "admin@example.com", Password: "hash", Role: domain.RoleAdmin},
+		{ID: 2, Email: "user@example.com", Password: "hash", Role: domain.RoleUser},
+	}, nil)
+
+	users, err := service.ListUsers(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	for _, user := range users {
+		assert.Empty(t, user.Password)
+	}
 }
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"time"
+	"video-processor/internal/core/domain"
+)
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token can be used
+// once; presenting an already rotated token means it leaked, so the whole family is revoked.
+func (s *userService) Refresh(ctx context.Context, refreshToken string) (domain.AuthResponse, error) {
+	stored, err := s.tokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if stored == nil || stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return domain.AuthResponse{}, domain.ErrInvalidToken
+	}
+
+	if stored.RotatedAt != nil {
+		return domain.AuthResponse{}, s.revokeReusedFamily(ctx, stored)
+	}
+
+	rotated, err := s.tokenRepo.MarkRotated(ctx, stored.ID)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if !rotated {
+		// Lost a race against another refresh using the same token
+		return domain.AuthResponse{}, s.revokeReusedFamily(ctx, stored)
+	}
+
+	user, err := s.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	if user == nil {
+		return domain.AuthResponse{}, domain.ErrInvalidToken
+	}
+	user.Password = ""
+
+	return s.issueTokens(ctx, user, stored.FamilyID, stored.AuthenticatedAt)
+}
+
+// Logout revokes the session the refresh token belongs to. Unknown tokens are ignored
+// so logging out twice is not an error.
+func (s *userService) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := s.tokenRepo.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return nil
+	}
+	return s.tokenRepo.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (s *userService) ValidateToken(ctx context.Context, accessToken string) (*domain.Principal, error) {
+	claims, err := s.signer.Verify(accessToken)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if typ, _ := claims["typ"].(string); typ != "" {
+		// E.g. an MFA challenge token, which must never grant access
+		return nil, domain.ErrInvalidToken
+	}
+	userID, ok := claims["sub"].(float64)
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+	sessionID, ok := claims["sid"].(string)
+	if !ok || sessionID == "" {
+		return nil, domain.ErrInvalidToken
+	}
+	email, _ := claims["email"].(string)
+	role, _ := claims["role"].(string)
+	// Tokens issued before auth_time existed read as an old login
+	authTime, _ := claims["auth_time"].(float64)
+	if role == "" {
+		role = domain.RoleUser
+	}
+
+	revoked, err := s.tokenRepo.IsFamilyRevoked(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrSessionRevoked
+	}
+
+	return &domain.Principal{
+		UserID:    int64(userID),
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
+		// The claim only has second precision
+		AuthenticatedAt: time.Unix(int64(authTime), 0),
+	}, nil
+}
+
+// startSession opens a new token family for a fresh login
+func (s *userService) startSession(ctx context.Context, user *domain.User) (domain.AuthResponse, error) {
+	familyID, err := randomToken(16)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+	return s.issueTokens(ctx, user, familyID, time.Now())
+}
+
+func (s *userService) issueTokens(ctx context.Context, user *domain.User, familyID string, authenticatedAt time.Time) (domain.AuthResponse, error) {
+	accessToken, err := s.generateToken(user, familyID, authenticatedAt)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	err = s.tokenRepo.Create(ctx, &domain.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+		// Carried over on rotation, so refreshing does not count as logging in again
+		AuthenticatedAt: authenticatedAt,
+	})
+	if err != nil {
+		return domain.AuthResponse{}, err
+	}
+
+	return domain.AuthResponse{
+		User:         *user,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.accessTTL.Seconds()),
+	}, nil
+}
+
+func (s *userService) generateToken(user *domain.User, sessionID string, authenticatedAt time.Time) (string, error) {
+	now := time.Now()
+	claims := map[string]interface{}{
+		"sub":       user.ID,
+		"email":     user.Email,
+		"role":      user.Role,
+		"sid":       sessionID,
+		"auth_time": authenticatedAt.Unix(),
+		"iat":       now.Unix(),
+		"exp":       now.Add(s.accessTTL).Unix(),
+	}
+
+	return s.signer.Sign(claims)
+}
+
+func (s *userService) revokeReusedFamily(ctx context.Context, stored *domain.RefreshToken) error {
+	log.Printf("⚠️ Reuso de refresh token detectado: user_id=%d, family_id=%s. Sessão revogada.", stored.UserID, stored.FamilyID)
+	// Revoked even if the client hangs up, since the token may be in an attacker's hands
+	if err := s.tokenRepo.RevokeFamily(context.WithoutCancel(ctx), stored.FamilyID); err != nil {
+		return err
+	}
+	return domain.ErrSessionRevoked
+}
+
+// randomToken returns n random bytes encoded as URL-safe base64
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken stores opaque high-entropy tokens as SHA-256, so a database leak does not expose them
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+	"video-processor/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserService_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	jwtSecret := "test-secret"
+
+	t.Run("success rotates token in same family", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(repo, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
+
+		loggedInAt := time.Now().Add(-time.Hour)
+		stored := &domain.RefreshToken{ID: 7, UserID: 1, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour), AuthenticatedAt: loggedInAt}
+		tokenRepo.On("GetByHash", mock.Anything, hashToken("old-token")).Return(stored, nil)
+		tokenRepo.On("MarkRotated", mock.Anything, int64(7)).Return(true, nil)
+		tokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.RefreshToken) bool {
+			return token.FamilyID == "family-1" && token.UserID == 1 && token.TokenHash != hashToken("old-token") &&
+				token.AuthenticatedAt.Equal(loggedInAt)
+		})).Return(nil)
+		repo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "test@example.com", Password: "hash"}, nil)
+
+		resp, err := service.Refresh(ctx, "old-token")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.Token)
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.NotEqual(t, "old-token", resp.RefreshToken)
+		assert.Empty(t, resp.User.Password)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
+
+		tokenRepo.On("GetByHash", mock.Anything, hashToken("missing")).Return(nil, nil)
+
+		_, err := service.Refresh(ctx, "missing")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
+
+		stored := &domain.RefreshToken{ID: 7, FamilyID: "family-1", ExpiresAt: time.Now().Add(-time.Minute)}
+		tokenRepo.On("GetByHash", mock.Anything, hashToken("expired")).Return(stored, nil)
+
+		_, err := service.Refresh(ctx, "expired")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		tokenRepo.AssertNotCalled(t, "MarkRotated", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reused token revokes the family", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
+
+		rotatedAt := time.Now().Add(-time.Minute)
+		stored := &domain.RefreshToken{ID: 7, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour), RotatedAt: &rotatedAt}
+		tokenRepo.On("GetByHash", mock.Anything, hashToken("reused")).Return(stored, nil)
+		tokenRepo.On("RevokeFamily", mock.Anything, "family-1").Return(nil)
+
+		_, err := service.Refresh(ctx, "reused")
+
+		assert.ErrorIs(t, err, domain.ErrSessionRevoked)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("concurrent rotation counts as reuse", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner(jwtSecret))
+
+		stored := &domain.RefreshToken{ID: 7, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+		tokenRepo.On("GetByHash", mock.Anything, hashToken("raced")).Return(stored, nil)
+		tokenRepo.On("MarkRotated", mock.Anything, int64(7)).Return(false, nil)
+		tokenRepo.On("RevokeFamily", mock.Anything, "family-1").Return(nil)
+
+		_, err := service.Refresh(ctx, "raced")
+
+		assert.ErrorIs(t, err, domain.ErrSessionRevoked)
+		tokenRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_Logout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("revokes family", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner("test-secret"))
+
+		tokenRepo.On("GetByHash", mock.Anything, hashToken("token")).Return(&domain.RefreshToken{ID: 1, FamilyID: "family-1"}, nil)
+		tokenRepo.On("RevokeFamily", mock.Anything, "family-1").Return(nil)
+
+		assert.NoError(t, service.Logout(ctx, "token"))
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown token is ignored", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner("test-secret"))
+
+		tokenRepo.On("GetByHash", mock.Anything, hashToken("token")).Return(nil, nil)
+
+		assert.NoError(t, service.Logout(ctx, "token"))
+		tokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_ValidateToken(t *testing.T) {
+	ctx := context.Background()
+
+	newSession := func(t *testing.T, tokenRepo *MockRefreshTokenRepository) (*userService, domain.AuthResponse) {
+		service := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner("test-secret")).(*userService)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+		resp, err := service.issueTokens(ctx, &domain.User{ID: 42, Email: "test@example.com", Role: domain.RoleAdmin}, "family-1", time.Unix(1700000000, 0))
+		assert.NoError(t, err)
+		return service, resp
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service, resp := newSession(t, tokenRepo)
+		tokenRepo.On("IsFamilyRevoked", mock.Anything, "family-1").Return(false, nil)
+
+		principal, err := service.ValidateToken(ctx, resp.Token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), principal.UserID)
+		assert.Equal(t, domain.RoleAdmin, principal.Role)
+		assert.Equal(t, "family-1", principal.SessionID)
+		assert.Equal(t, time.Unix(1700000000, 0), principal.AuthenticatedAt)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service, resp := newSession(t, tokenRepo)
+		tokenRepo.On("IsFamilyRevoked", mock.Anything, "family-1").Return(true, nil)
+
+		_, err := service.ValidateToken(ctx, resp.Token)
+
+		assert.ErrorIs(t, err, domain.ErrSessionRevoked)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		_, resp := newSession(t, tokenRepo)
+		other := NewUserService(nil, tokenRepo, nil, nil, nil, nil, newTestSigner("other-secret"))
+
+		_, err := other.ValidateToken(ctx, resp.Token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
@@ -1,11 +1,15 @@
 package services
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 	"video-processor/internal/core/domain"
 	"video-processor/internal/core/ports"
 )
@@ -13,18 +17,24 @@ import (
 type videoService struct {
 	storage   ports.Storage
 	repo      ports.VideoRepository
+	orgs      ports.OrganizationRepository
 	publisher ports.EventPublisher
 }
 
-func NewVideoService(s ports.Storage, r ports.VideoRepository, p ports.EventPublisher) ports.VideoUseCase {
+func NewVideoService(s ports.Storage, r ports.VideoRepository, o ports.OrganizationRepository, p ports.EventPublisher) ports.VideoUseCase {
 	return &videoService{
 		storage:   s,
 		repo:      r,
+		orgs:      o,
 		publisher: p,
 	}
 }
 
-func (s *videoService) UploadAndProcess(userID int64, filename string, file io.Reader) (domain.ProcessingResult, error) {
+// maxOriginalFilenameLength matches the size of videos.original_filename
+const maxOriginalFilenameLength = 255
+
+func (s *videoService) UploadAndProcess(ctx context.Context, userID int64, organizationID *int64, filename string, file io.Reader) (domain.ProcessingResult, error) {
+	filename = sanitizeOriginalFilename(filename)
 	if !s.isValidVideoFile(filename) {
 		return domain.ProcessingResult{
 			Success:   false,
@@ -33,11 +43,28 @@ func (s *videoService) UploadAndProcess(userID int64, filename string, file io.R
 		}, nil
 	}
 
-	timestamp := time.Now().Format("20060102_150405")
-	uniqueID := time.Now().UnixNano()
-	uniqueFilename := fmt.Sprintf("%s_%d_%s", timestamp, uniqueID, filename)
+	if organizationID != nil {
+		if _, err := requireOrgRole(ctx, s.orgs, *organizationID, userID, domain.OrgRoleEditor); err != nil {
+			return domain.ProcessingResult{
+				Success:   false,
+				Message:   err.Error(),
+				ErrorCode: "ERR_FORBIDDEN",
+			}, err
+		}
+	}
 
-	videoPath, err := s.storage.SaveUpload(uniqueFilename, file)
+	storageKey, err := newStorageKey(filename)
+	if err != nil {
+		return domain.ProcessingResult{
+			Success:   false,
+			Message:   "Erro ao gerar identificador do arquivo: " + err.Error(),
+			ErrorCode: "ERR_STORAGE_FAIL",
+		}, err
+	}
+
+	// Storage is not transactional and streaming an upload must not hold a database
+	// connection, so the file is written first and removed if the record cannot be stored
+	videoPath, err := s.storage.SaveUpload(ctx, storageKey, file)
 	if err != nil {
 		return domain.ProcessingResult{
 			Success:   false,
@@ -47,14 +74,16 @@ func (s *videoService) UploadAndProcess(userID int64, filename string, file io.R
 	}
 
 	video := &domain.Video{
-		UserID:   userID,
-		Filename: uniqueFilename, // Store the unique filename so worker can find it
-		Status:   domain.StatusPending,
+		UserID:           userID,
+		OrganizationID:   organizationID,
+		OriginalFilename: filename,
+		StorageKey:       storageKey, // The worker finds the upload by its storage key
+		Status:           domain.StatusPending,
 	}
 
-	err = s.repo.Create(video)
-	if err != nil {
-		s.storage.DeleteFile(videoPath)
+	if err := s.repo.Create(ctx, video); err != nil {
+		// Clean up even when the failure was the client going away
+		s.storage.DeleteFile(context.WithoutCancel(ctx), videoPath)
 		return domain.ProcessingResult{
 			Success:   false,
 			Message:   "Erro ao criar registro no banco: " + err.Error(),
@@ -62,8 +91,9 @@ func (s *videoService) UploadAndProcess(userID int64, filename string, file io.R
 		}, err
 	}
 
-	// Publish NATS event
-	err = s.publisher.PublishUploadEvent(video.ID, video.Filename)
+	// Publish NATS event only after the insert, so the worker never sees an uncommitted video.
+	// The video is already stored, so a client disconnecting now must not leave it pending forever.
+	err = s.publisher.PublishUploadEvent(context.WithoutCancel(ctx), video.ID, video.StorageKey)
 	if err != nil {
 		// Log error but don't fail the upload since it's already in DB/Storage
 		// or should we fail it? Usually, we want the event to be published.
@@ -78,12 +108,70 @@ func (s *videoService) UploadAndProcess(userID int64, filename string, file io.R
 	}, nil
 }
 
-func (s *videoService) ListProcessedFiles() ([]domain.FileInfo, error) {
-	return s.storage.ListOutputs()
+func (s *videoService) ListProcessedFiles(ctx context.Context) ([]domain.FileInfo, error) {
+	return s.storage.ListOutputs(ctx)
+}
+
+func (s *videoService) GetVideosByUserID(ctx context.Context, userID int64) ([]domain.Video, error) {
+	return s.repo.ListAccessibleByUserID(ctx, userID)
+}
+
+func (s *videoService) GetUserVideo(ctx context.Context, userID, videoID int64) (*domain.Video, error) {
+	video, err := s.repo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	// Videos the user cannot see are reported as missing so their existence is not leaked
+	if video == nil {
+		return nil, domain.ErrVideoNotFound
+	}
+	if video.OrganizationID != nil {
+		// Every role may download the organization's videos
+		member, err := s.orgs.GetMember(ctx, *video.OrganizationID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, domain.ErrVideoNotFound
+		}
+		return video, nil
+	}
+	if video.UserID != userID {
+		return nil, domain.ErrVideoNotFound
+	}
+	return video, nil
+}
+
+func (s *videoService) ListAllVideos(ctx context.Context) ([]domain.Video, error) {
+	return s.repo.List(ctx)
 }
 
-func (s *videoService) GetVideosByUserID(userID int64) ([]domain.Video, error) {
-	return s.repo.GetByUserID(userID)
+// FailVideo lets an administrator abort a stuck job. Finished videos are left untouched.
+func (s *videoService) FailVideo(ctx context.Context, videoID int64, reason string) (*domain.Video, error) {
+	video, err := s.repo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video == nil {
+		return nil, domain.ErrVideoNotFound
+	}
+	if video.Status == domain.StatusCompleted || video.Status == domain.StatusFailed {
+		return nil, domain.ErrVideoFinished
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		reason = "Processamento interrompido por um administrador"
+	}
+	now := time.Now()
+	video.Status = domain.StatusFailed
+	video.Message = reason
+	video.ErrorCode = domain.ErrCodeAborted
+	video.ProcessingFinishedAt = &now
+
+	if err := s.repo.Update(ctx, video); err != nil {
+		return nil, err
+	}
+	return video, nil
 }
 
 func (s *videoService) isValidVideoFile(filename string) bool {
@@ -97,3 +185,37 @@ func (s *videoService) isValidVideoFile(filename string) bool {
 	}
 	return false
 }
+
+// sanitizeOriginalFilename keeps the name the user sees, dropping any client-supplied
+// directories and control characters. It is never used to build a storage path.
+func sanitizeOriginalFilename(filename string) string {
+	filename = strings.ReplaceAll(filename, "\\", "/")
+	filename = filename[strings.LastIndex(filename, "/")+1:]
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, filename)
+	filename = strings.TrimSpace(filename)
+
+	if runes := []rune(filename); len(runes) > maxOriginalFilenameLength {
+		ext := []rune(filepath.Ext(filename))
+		if len(ext) >= maxOriginalFilenameLength {
+			ext = nil
+		}
+		filename = string(runes[:maxOriginalFilenameLength-len(ext)]) + string(ext)
+	}
+	return filename
+}
+
+// newStorageKey builds a unique, filesystem-safe key made only of a timestamp, random
+// bytes and the (already validated) lowercase extension of the uploaded file.
+func newStorageKey(filename string) (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	return fmt.Sprintf("%s_%s%s", time.Now().UTC().Format("20060102_150405"), hex.EncodeToString(random), ext), nil
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"strings"
 	"testing"
 	"video-processor/internal/core/domain"
 
@@ -11,38 +13,127 @@ import (
 )
 
 func TestVideoService_UploadAndProcess(t *testing.T) {
+	ctx := context.Background()
+
 	t.Run("success", func(t *testing.T) {
 		storage := new(MockStorage)
 		repo := new(MockVideoRepository)
 		publisher := new(MockEventPublisher)
-		service := NewVideoService(storage, repo, publisher)
+		service := NewVideoService(storage, repo, nil, publisher)
 
 		userID := int64(1)
 		filename := "video.mp4"
 		fileContent := []byte("fake video content")
 		reader := bytes.NewReader(fileContent)
 
-		storage.On("SaveUpload", mock.AnythingOfType("string"), mock.Anything).Return("/path/to/video.mp4", nil)
-		repo.On("Create", mock.AnythingOfType("*domain.Video")).Return(nil).Run(func(args mock.Arguments) {
-			video := args.Get(0).(*domain.Video)
-			video.ID = 100
+		var created *domain.Video
+		storage.On("SaveUpload", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return("/path/to/video.mp4", nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Video")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(1).(*domain.Video)
+			created.ID = 100
 		})
-		publisher.On("PublishUploadEvent", int64(100), mock.AnythingOfType("string")).Return(nil)
+		publisher.On("PublishUploadEvent", mock.Anything, int64(100), mock.AnythingOfType("string")).Return(nil)
 
-		resp, err := service.UploadAndProcess(userID, filename, reader)
+		resp, err := service.UploadAndProcess(ctx, userID, nil, filename, reader)
 
 		assert.NoError(t, err)
 		assert.True(t, resp.Success)
 		assert.Equal(t, int64(100), resp.VideoID)
+		assert.Equal(t, filename, created.OriginalFilename)
+		assert.NotContains(t, created.StorageKey, filename)
+		assert.True(t, strings.HasSuffix(created.StorageKey, ".mp4"))
+		storage.AssertCalled(t, "SaveUpload", mock.Anything, created.StorageKey, mock.Anything)
+		publisher.AssertCalled(t, "PublishUploadEvent", mock.Anything, int64(100), created.StorageKey)
 		storage.AssertExpectations(t)
 		repo.AssertExpectations(t)
 		publisher.AssertExpectations(t)
 	})
 
+	t.Run("storage key ignores path and unicode in filename", func(t *testing.T) {
+		storage := new(MockStorage)
+		repo := new(MockVideoRepository)
+		publisher := new(MockEventPublisher)
+		service := NewVideoService(storage, repo, nil, publisher)
+
+		var created *domain.Video
+		storage.On("SaveUpload", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return("/path/to/key", nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Video")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(1).(*domain.Video)
+		})
+		publisher.On("PublishUploadEvent", mock.Anything, mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+		resp, err := service.UploadAndProcess(ctx, 1, nil, "../../etc/Férias 🎉.MOV", bytes.NewReader([]byte("video")))
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.Equal(t, "Férias 🎉.MOV", created.OriginalFilename)
+		assert.Regexp(t, `^\d{8}_\d{6}_[0-9a-f]{16}\.mov$`, created.StorageKey)
+	})
+
+	t.Run("publishes after the client disconnects", func(t *testing.T) {
+		storage := new(MockStorage)
+		repo := new(MockVideoRepository)
+		publisher := new(MockEventPublisher)
+		service := NewVideoService(storage, repo, nil, publisher)
+
+		reqCtx, cancel := context.WithCancel(ctx)
+		storage.On("SaveUpload", reqCtx, mock.AnythingOfType("string"), mock.Anything).Return("/path/to/key", nil)
+		repo.On("Create", reqCtx, mock.AnythingOfType("*domain.Video")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Video).ID = 5
+			cancel()
+		})
+		publisher.On("PublishUploadEvent", mock.MatchedBy(func(c context.Context) bool {
+			return c.Err() == nil
+		}), int64(5), mock.AnythingOfType("string")).Return(nil)
+
+		resp, err := service.UploadAndProcess(reqCtx, 1, nil, "video.mp4", bytes.NewReader([]byte("video")))
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("organization editor", func(t *testing.T) {
+		storage := new(MockStorage)
+		repo := new(MockVideoRepository)
+		orgs := new(MockOrganizationRepository)
+		publisher := new(MockEventPublisher)
+		service := NewVideoService(storage, repo, orgs, publisher)
+
+		orgID := int64(3)
+		orgs.On("GetMember", mock.Anything, orgID, int64(1)).Return(&domain.OrganizationMember{OrganizationID: orgID, UserID: 1, Role: domain.OrgRoleEditor}, nil)
+		storage.On("SaveUpload", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return("/path/to/key", nil)
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(v *domain.Video) bool {
+			return v.UserID == 1 && v.OrganizationID != nil && *v.OrganizationID == orgID
+		})).Return(nil)
+		publisher.On("PublishUploadEvent", mock.Anything, mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+		resp, err := service.UploadAndProcess(ctx, 1, &orgID, "video.mp4", bytes.NewReader([]byte("video")))
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("organization viewer cannot upload", func(t *testing.T) {
+		storage := new(MockStorage)
+		orgs := new(MockOrganizationRepository)
+		service := NewVideoService(storage, nil, orgs, nil)
+
+		orgID := int64(3)
+		orgs.On("GetMember", mock.Anything, orgID, int64(1)).Return(&domain.OrganizationMember{OrganizationID: orgID, UserID: 1, Role: domain.OrgRoleViewer}, nil)
+
+		resp, err := service.UploadAndProcess(ctx, 1, &orgID, "video.mp4", bytes.NewReader([]byte("video")))
+
+		assert.ErrorIs(t, err, domain.ErrOrgPermissionDenied)
+		assert.False(t, resp.Success)
+		storage.AssertNotCalled(t, "SaveUpload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("invalid file format", func(t *testing.T) {
-		service := NewVideoService(nil, nil, nil)
+		service := NewVideoService(nil, nil, nil, nil)
 
-		resp, err := service.UploadAndProcess(1, "test.txt", bytes.NewReader([]byte("txt")))
+		resp, err := service.UploadAndProcess(ctx, 1, nil, "test.txt", bytes.NewReader([]byte("txt")))
 
 		assert.NoError(t, err)
 		assert.False(t, resp.Success)
@@ -51,61 +142,230 @@ func TestVideoService_UploadAndProcess(t *testing.T) {
 
 	t.Run("storage error", func(t *testing.T) {
 		storage := new(MockStorage)
-		service := NewVideoService(storage, nil, nil)
+		service := NewVideoService(storage, nil, nil, nil)
 
-		storage.On("SaveUpload", mock.AnythingOfType("string"), mock.Anything).Return("", errors.New("storage fail"))
+		storage.On("SaveUpload", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return("", errors.New("storage fail"))
 
-		resp, err := service.UploadAndProcess(1, "video.mp4", bytes.NewReader([]byte("video")))
+		resp, err := service.UploadAndProcess(ctx, 1, nil, "video.mp4", bytes.NewReader([]byte("video")))
 
 		assert.Error(t, err)
 		assert.False(t, resp.Success)
 		assert.Equal(t, "storage fail", err.Error())
 	})
 
-	t.Run("repo error", func(t *testing.T) {
+	t.Run("repo error removes the upload", func(t *testing.T) {
 		storage := new(MockStorage)
 		repo := new(MockVideoRepository)
-		service := NewVideoService(storage, repo, nil)
+		service := NewVideoService(storage, repo, nil, nil)
 
-		storage.On("SaveUpload", mock.AnythingOfType("string"), mock.Anything).Return("/path/to/video.mp4", nil)
-		storage.On("DeleteFile", "/path/to/video.mp4").Return(nil)
-		repo.On("Create", mock.AnythingOfType("*domain.Video")).Return(errors.New("db error"))
+		storage.On("SaveUpload", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return("/path/to/video.mp4", nil)
+		storage.On("DeleteFile", mock.Anything, "/path/to/video.mp4").Return(nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Video")).Return(errors.New("db error"))
 
-		resp, err := service.UploadAndProcess(1, "video.mp4", bytes.NewReader([]byte("video")))
+		resp, err := service.UploadAndProcess(ctx, 1, nil, "video.mp4", bytes.NewReader([]byte("video")))
 
 		assert.Error(t, err)
 		assert.False(t, resp.Success)
 		assert.Equal(t, "db error", err.Error())
+		assert.Equal(t, "ERR_DB_FAIL", resp.ErrorCode)
+		storage.AssertExpectations(t)
 	})
 }
 
 func TestVideoService_ListProcessedFiles(t *testing.T) {
+	ctx := context.Background()
+
 	storage := new(MockStorage)
-	service := NewVideoService(storage, nil, nil)
+	service := NewVideoService(storage, nil, nil, nil)
 
 	expectedFiles := []domain.FileInfo{{Name: "file1.zip"}, {Name: "file2.zip"}}
-	storage.On("ListOutputs").Return(expectedFiles, nil)
+	storage.On("ListOutputs", mock.Anything).Return(expectedFiles, nil)
 
-	files, err := service.ListProcessedFiles()
+	files, err := service.ListProcessedFiles(ctx)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedFiles, files)
 }
 
 func TestVideoService_GetVideosByUserID(t *testing.T) {
+	ctx := context.Background()
+
 	repo := new(MockVideoRepository)
-	service := NewVideoService(nil, repo, nil)
+	service := NewVideoService(nil, repo, nil, nil)
 
 	userID := int64(1)
 	expectedVideos := []domain.Video{{ID: 1, UserID: userID}, {ID: 2, UserID: userID}}
-	repo.On("GetByUserID", userID).Return(expectedVideos, nil)
+	repo.On("ListAccessibleByUserID", mock.Anything, userID).Return(expectedVideos, nil)
+
+	videos, err := service.GetVideosByUserID(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedVideos, videos)
+}
+
+func TestVideoService_GetUserVideo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("owner", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		service := NewVideoService(nil, repo, nil, nil)
+
+		expected := &domain.Video{ID: 10, UserID: 1}
+		repo.On("GetByID", mock.Anything, int64(10)).Return(expected, nil)
+
+		video, err := service.GetUserVideo(ctx, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, video)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		service := NewVideoService(nil, repo, nil, nil)
+
+		repo.On("GetByID", mock.Anything, int64(10)).Return(&domain.Video{ID: 10, UserID: 2}, nil)
+
+		video, err := service.GetUserVideo(ctx, 1, 10)
+
+		assert.Nil(t, video)
+		assert.ErrorIs(t, err, domain.ErrVideoNotFound)
+	})
+
+	t.Run("organization member", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		orgs := new(MockOrganizationRepository)
+		service := NewVideoService(nil, repo, orgs, nil)
+
+		orgID := int64(3)
+		expected := &domain.Video{ID: 10, UserID: 2, OrganizationID: &orgID}
+		repo.On("GetByID", mock.Anything, int64(10)).Return(expected, nil)
+		orgs.On("GetMember", mock.Anything, orgID, int64(1)).Return(&domain.OrganizationMember{OrganizationID: orgID, UserID: 1, Role: domain.OrgRoleViewer}, nil)
+
+		video, err := service.GetUserVideo(ctx, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, video)
+	})
+
+	t.Run("uploader outside the organization", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		orgs := new(MockOrganizationRepository)
+		service := NewVideoService(nil, repo, orgs, nil)
+
+		orgID := int64(3)
+		repo.On("GetByID", mock.Anything, int64(10)).Return(&domain.Video{ID: 10, UserID: 1, OrganizationID: &orgID}, nil)
+		orgs.On("GetMember", mock.Anything, orgID, int64(1)).Return(nil, nil)
+
+		video, err := service.GetUserVideo(ctx, 1, 10)
+
+		assert.Nil(t, video)
+		assert.ErrorIs(t, err, domain.ErrVideoNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		service := NewVideoService(nil, repo, nil, nil)
 
-	videos, err := service.GetVideosByUserID(userID)
+		repo.On("GetByID", mock.Anything, int64(10)).Return(nil, nil)
+
+		_, err := service.GetUserVideo(ctx, 1, 10)
+
+		assert.ErrorIs(t, err, domain.ErrVideoNotFound)
+	})
+}
+
+func TestVideoService_ListAllVideos(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(MockVideoRepository)
+	service := NewVideoService(nil, repo, nil, nil)
+
+	expectedVideos := []domain.Video{{ID: 1, UserID: 1}, {ID: 2, UserID: 2}}
+	repo.On("List", mock.Anything).Return(expectedVideos, nil)
+
+	videos, err := service.ListAllVideos(ctx)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedVideos, videos)
 }
 
+func TestVideoService_FailVideo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("processing video", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		service := NewVideoService(nil, repo, nil, nil)
+
+		repo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Video{ID: 5, Status: domain.StatusProcessing}, nil)
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(v *domain.Video) bool {
+			return v.Status == domain.StatusFailed && v.Message == "worker travado" &&
+				v.ErrorCode == domain.ErrCodeAborted && v.ProcessingFinishedAt != nil
+		})).Return(nil)
+
+		video, err := service.FailVideo(ctx, 5, "worker travado")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusFailed, video.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("default reason", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		service := NewVideoService(nil, repo, nil, nil)
+
+		repo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Video{ID: 5, Status: domain.StatusPending}, nil)
+		repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Video")).Return(nil)
+
+		video, err := service.FailVideo(ctx, 5, "  ")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, video.Message)
+	})
+
+	t.Run("already completed", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		service := NewVideoService(nil, repo, nil, nil)
+
+		repo.On("GetByID", mock.Anything, int64(5)).Return(&domain.Video{ID: 5, Status: domain.StatusCompleted}, nil)
+
+		_, err := service.FailVideo(ctx, 5, "")
+
+		assert.ErrorIs(t, err, domain.ErrVideoFinished)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := new(MockVideoRepository)
+		service := NewVideoService(nil, repo, nil, nil)
+
+		repo.On("GetByID", mock.Anything, int64(5)).Return(nil, nil)
+
+		_, err := service.FailVideo(ctx, 5, "")
+
+		assert.ErrorIs(t, err, domain.ErrVideoNotFound)
+	})
+}
+
+func TestSanitizeOriginalFilename(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"video.mp4", "video.mp4"},
+		{"C:\\Users\\me\\clip.avi", "clip.avi"},
+		{"../../secret/movie.mkv", "movie.mkv"},
+		{"  spaced\x00name\n.mp4 ", "spacedname.mp4"},
+		{"vídeo ação.mov", "vídeo ação.mov"},
+		{strings.Repeat("á", 300) + ".mp4", strings.Repeat("á", 251) + ".mp4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, sanitizeOriginalFilename(tt.in))
+		})
+	}
+}
+
 func TestVideoService_IsValidVideoFile(t *testing.T) {
 	service := &videoService{}
 
@@ -19,118 +19,226 @@ package main
 // @name Authorization
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	inbound_http "video-processor/internal/adapters/inbound/http"
+	outbound_mail "video-processor/internal/adapters/outbound/mail"
+	outbound_memory "video-processor/internal/adapters/outbound/memory"
 	outbound_messaging "video-processor/internal/adapters/outbound/messaging"
+	outbound_oidc "video-processor/internal/adapters/outbound/oidc"
+	outbound_ratelimit "video-processor/internal/adapters/outbound/ratelimit"
 	outbound_repository "video-processor/internal/adapters/outbound/repository"
+	outbound_signing "video-processor/internal/adapters/outbound/signing"
 	outbound_storage "video-processor/internal/adapters/outbound/storage"
+	"video-processor/internal/config"
+	"video-processor/internal/core/domain"
+	"video-processor/internal/core/ports"
 	core_services "video-processor/internal/core/services"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	ginprometheus "github.com/zsais/go-gin-prometheus"
 
 	"context"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-migrate/migrate/v4"
 
 	_ "video-processor/docs"
 
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const usage = `uso: video-processor [comando]
+
+comandos:
+  serve [--dev]   inicia a API (padrão)
+  migrate ...     gerencia as migrações do banco; veja video-processor migrate
+`
+
 func main() {
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		serve(args)
+	case "migrate":
+		if err := runMigrate(args); err != nil {
+			log.Fatal("❌ ", err)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func serve(args []string) {
 	fmt.Println("Starting Video Processor API...")
 
-	// Database initialization
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASSWORD")
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	devMode := flags.Bool("dev", false, "run without Postgres and NATS, keeping data in memory and files in a temp dir, or users and videos in SQLite with DB_DRIVER=sqlite")
+	flags.Parse(args)
 
-	if dbHost == "" {
-		dbHost = "localhost"
+	cfg, err := config.Load(*devMode)
+	if err != nil {
+		log.Fatal("❌ ", err)
 	}
 
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPass, dbHost, dbPort, dbName)
+	// ctx is cancelled on SIGINT/SIGTERM and stops background jobs before the server drains
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Run migrations
-	m, err := migrate.New("file://migrations", connStr)
-	if err != nil {
-		log.Printf("⚠️ Erro ao preparar migrações: %v", err)
+	// Initialize Outbound Adapters
+	var repos *repositories
+	var eventPublisher ports.EventPublisher
+	var natsErr error
+	cleanup := func() {}
+	if cfg.Dev && cfg.Database.Driver == config.DriverSQLite {
+		// Files stay in the configured directories, since the videos pointing at them survive a restart
+		log.Printf("⚠️ Modo de desenvolvimento com SQLite: usuários e vídeos persistidos em %s; sessões, chaves de API, MFA, organizações e demais dados ficam em memória, perdidos ao encerrar. Nunca use em produção!", cfg.Database.Path)
+		if repos, err = newSQLiteRepositories(cfg); err != nil {
+			log.Fatal("❌ Erro ao conectar ao banco de dados: ", err)
+		}
+		eventPublisher = outbound_memory.NewMemoryEventPublisher()
+	} else if cfg.Dev {
+		log.Printf("⚠️ Modo de desenvolvimento: dados em memória, perdidos ao encerrar. Nunca use em produção!")
+		tempDir, err := useTempStorage(&cfg.Storage)
+		if err != nil {
+			log.Fatal("❌ Erro ao criar diretório temporário: ", err)
+		}
+		cleanup = func() { os.RemoveAll(tempDir) }
+		fmt.Printf("📂 Arquivos em: %s\n", tempDir)
+
+		repos = newMemoryRepositories()
+		eventPublisher = outbound_memory.NewMemoryEventPublisher()
 	} else {
-		if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-			log.Printf("⚠️ Erro ao aplicar migrações: %v", err)
-		} else {
-			fmt.Println("✅ Migrações aplicadas com sucesso!")
+		repos, err = newPostgresRepositories(cfg)
+		if err != nil {
+			log.Fatal("❌ Erro ao conectar ao banco de dados: ", err)
+		}
+
+		// Initialize NATS
+		eventPublisher, natsErr = outbound_messaging.NewNatsAdapter(cfg.NATS.URL, cfg.Timeouts.Publish.Std())
+		if natsErr != nil {
+			log.Printf("⚠️ Erro ao conectar ao NATS: %v. O sistema continuará sem publicação de eventos.", natsErr)
 		}
 	}
+	storage := outbound_storage.NewFSStorage(cfg.Storage.UploadDir, cfg.Storage.OutputDir, cfg.Storage.TempDir, cfg.Timeouts.Storage.Std())
 
-	dbPool, err := pgxpool.New(context.Background(), connStr)
+	// Token signing
+	tokenSigner, err := newTokenSigner(ctx, cfg.JWT, cfg.IsDevelopment())
 	if err != nil {
-		log.Fatal("❌ Erro ao conectar ao banco de dados: ", err)
+		log.Fatal("❌ Erro ao configurar assinatura de tokens: ", err)
 	}
-	defer dbPool.Close()
-
-	// Initialize Outbound Adapters
-	storage := outbound_storage.NewFSStorage()
-	userRepo := outbound_repository.NewPostgresUserRepository(dbPool)
-	videoRepo := outbound_repository.NewPostgresVideoRepository(dbPool)
 
-	// Initialize NATS
-	natsURL := os.Getenv("NATS_URL")
-	if natsURL == "" {
-		natsURL = "nats://nats1:4222"
-	}
-	eventPublisher, err := outbound_messaging.NewNatsAdapter(natsURL)
+	mailer, err := newMailer(cfg.Mail)
 	if err != nil {
-		log.Printf("⚠️ Erro ao conectar ao NATS: %v. O sistema continuará sem publicação de eventos.", err)
-		// We could use a mock or NullPublisher here if we wanted to be more robust
-		// For now, let's just log and see. But NewVideoService expects a port.
-		// I'll implement a simple NoOp publisher in case of error.
+		log.Fatal("❌ Erro ao configurar envio de e-mails: ", err)
 	}
 
 	// Initialize Core Services
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "fiapx-secret-key"
+	videoService := core_services.NewVideoService(storage, repos.videos, repos.organizations, eventPublisher)
+	userService := core_services.NewUserService(repos.users, repos.refreshTokens, repos.identities, repos.mfa, repos.loginThrottles, repos.audit, tokenSigner)
+	apiKeyService := core_services.NewAPIKeyService(repos.apiKeys, repos.users)
+	mfaService := core_services.NewMFAService(repos.mfa, repos.users, repos.audit)
+	appBaseURL := cfg.Server.BaseURL
+	accountService := core_services.NewAccountService(repos.users, repos.accountTokens, repos.refreshTokens, mailer, appBaseURL)
+	profileService := core_services.NewProfileService(repos.users, repos.videos, repos.dataExports, storage, repos.refreshTokens, accountService, repos.audit, repos.unitOfWork)
+	organizationService := core_services.NewOrganizationService(repos.organizations, repos.users, repos.unitOfWork)
+	dataExportService := core_services.NewDataExportService(repos.dataExports, repos.users, repos.videos, storage, mailer, appBaseURL)
+	auditService := core_services.NewAuditService(repos.audit, cfg.Audit.Retention.Std())
+
+	// Readiness probes; NATS stays down for the process lifetime when the first connection failed
+	natsHealth := ports.HealthChecker(ports.HealthCheckFunc(func(context.Context) error { return natsErr }))
+	if eventPublisher != nil {
+		natsHealth = eventPublisher
+	}
+	healthService := core_services.NewHealthService(map[string]ports.HealthChecker{
+		"database": repos.health,
+		"nats":     natsHealth,
+		"storage":  storage,
+	})
+
+	// Exports left pending by a build that died with its process are failed, on startup and
+	// then periodically for builds lost by other instances
+	failInterruptedExports := func() {
+		if err := dataExportService.FailInterrupted(ctx); err != nil {
+			log.Printf("⚠️ Erro ao marcar exportações interrompidas: %v", err)
+		}
 	}
+	failInterruptedExports()
+
+	// Expired data export archives are removed once their download window closes
+	runEvery(ctx, time.Hour, func() {
+		failInterruptedExports()
+		if err := dataExportService.PurgeExpired(ctx); err != nil {
+			log.Printf("⚠️ Erro ao remover exportações expiradas: %v", err)
+		}
+	})
 
-	videoService := core_services.NewVideoService(storage, videoRepo, eventPublisher)
-	userService := core_services.NewUserService(userRepo, jwtSecret)
+	// Audit events older than the audit retention are purged; 0 keeps them forever
+	runEvery(ctx, time.Hour, func() {
+		deleted, err := auditService.PurgeExpired(ctx)
+		if err != nil {
+			log.Printf("⚠️ Erro ao remover eventos de auditoria antigos: %v", err)
+		} else if deleted > 0 {
+			log.Printf("✅ %d eventos de auditoria removidos pela retenção", deleted)
+		}
+	})
+
+	// Single sign-on is optional and only enabled when an issuer is configured
+	var ssoService ports.SSOUseCase
+	if cfg.OIDC.IssuerURL != "" {
+		identityProvider, err := outbound_oidc.NewOIDCProvider(cfg.OIDC.IssuerURL, cfg.OIDC.ClientID, cfg.OIDC.ClientSecret, cfg.OIDC.RedirectURL)
+		if err != nil {
+			log.Printf("⚠️ Erro ao configurar SSO: %v. O login SSO ficará desabilitado.", err)
+		} else {
+			ssoService = core_services.NewSSOService(identityProvider, repos.oidcStates, userService)
+		}
+	}
 
 	// Initialize Inbound Adapter (HTTP)
-	handler := inbound_http.NewHandler(videoService, userService, storage, jwtSecret)
+	handler := inbound_http.NewHandler(videoService, userService, apiKeyService, ssoService, accountService, mfaService, profileService, dataExportService, organizationService, auditService, healthService, tokenSigner, storage)
+	handler.RequireVerifiedEmailForUploads(cfg.RequireEmailVerification)
+	// Buckets live in this process; a shared ports.RateLimiter is needed to enforce limits across replicas
+	if cfg.RateLimit.Enabled {
+		handler.EnableRateLimiting(outbound_ratelimit.NewMemoryRateLimiter(), domain.DefaultRateLimitPolicies())
+	}
 
 	r := gin.Default()
+	r.Use(inbound_http.RequestID())
+
+	// Client IPs feed login throttling, so X-Forwarded-For is only honoured from known proxies
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("❌ TRUSTED_PROXIES inválido: ", err)
+	}
 
 	// Prometheus Metrics
+	// /metrics serves the default registry: the gin request metrics and the counters the
+	// adapters register with promauto, such as http_rate_limit_rejections_total
 	p := ginprometheus.NewPrometheus("gin")
-	p.Use(r)
-
-	// Middleware
-	r.Use(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-
-		c.Next()
-	})
+	r.Use(p.HandlerFunc())
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-	// Static files
-	r.Static("/uploads", "/app/uploads")
-	r.Static("/outputs", "/app/outputs")
+	// Cross-origin access for browser clients
+	cors, err := newCORS(cfg.CORS)
+	if err != nil {
+		log.Fatal("❌ Erro ao configurar CORS: ", err)
+	}
+	r.Use(cors)
 
 	// Swagger documentation
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -138,8 +246,300 @@ func main() {
 	// Register Routes
 	handler.RegisterRoutes(r)
 
-	fmt.Println("🎬 Servidor iniciado na porta 8080")
-	fmt.Println("📂 Acesse: http://localhost:8080")
+	srv := &http.Server{
+		Addr:    cfg.Addr(),
+		Handler: r,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	fmt.Printf("🎬 Servidor iniciado na porta %d\n", cfg.Server.Port)
+	fmt.Printf("📂 Acesse: %s\n", appBaseURL)
+
+	exitCode := 0
+	select {
+	case err := <-serverErr:
+		log.Printf("❌ Erro no servidor HTTP: %v", err)
+		exitCode = 1
+	case <-ctx.Done():
+		log.Printf("⚠️ Sinal de desligamento recebido. Aguardando requisições em andamento por até %s...", cfg.Server.ShutdownTimeout.Std())
+	}
+	// A second signal now terminates the process immediately
+	stop()
+
+	// Keep serving while reporting not ready so the orchestrator stops routing new traffic here
+	healthService.BeginShutdown()
+	if exitCode == 0 {
+		time.Sleep(cfg.Server.ShutdownDelay.Std())
+	}
+
+	if err := shutdown(srv, cfg.Server.ShutdownTimeout.Std(), dataExportService, eventPublisher, repos.close); err != nil {
+		log.Printf("❌ Desligamento incompleto: %v", err)
+		exitCode = 1
+	} else {
+		fmt.Println("✅ Servidor finalizado")
+	}
+	cleanup()
+	os.Exit(exitCode)
+}
+
+// shutdown stops accepting connections and waits up to timeout for in-flight requests,
+// including uploads still streaming, and for data exports being built, before flushing
+// pending NATS publishes and closing the database pool. Requests still running after the
+// timeout are cut off, and exports still being built are failed later by FailInterrupted.
+func shutdown(srv *http.Server, timeout time.Duration, exports ports.DataExportUseCase, publisher ports.EventPublisher, closeDB func()) error {
+	var errs []error
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("requisições interrompidas: %w", err))
+		srv.Close()
+	}
+
+	// Builds started by requests that just finished still need the database and storage
+	if err := exports.Wait(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("exportações de dados interrompidas: %w", err))
+	}
+
+	// Publishing happens inside requests, so draining after the server stops loses nothing
+	if publisher != nil {
+		if err := publisher.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	closeDB()
+	return errors.Join(errs...)
+}
+
+// repositories are the persistence adapters, backed by Postgres or, in dev mode, by memory and
+// optionally SQLite
+type repositories struct {
+	users          ports.UserRepository
+	videos         ports.VideoRepository
+	refreshTokens  ports.RefreshTokenRepository
+	apiKeys        ports.APIKeyRepository
+	identities     ports.UserIdentityRepository
+	accountTokens  ports.AccountTokenRepository
+	loginThrottles ports.LoginThrottleRepository
+	audit          ports.AuditRepository
+	mfa            ports.MFARepository
+	dataExports    ports.DataExportRepository
+	organizations  ports.OrganizationRepository
+	oidcStates     ports.OIDCStateRepository
+	unitOfWork     ports.UnitOfWork
+	health         ports.HealthChecker
+	close          func()
+}
+
+// newPostgresRepositories checks the schema and connects the repositories to the pool
+func newPostgresRepositories(cfg *config.Config) (*repositories, error) {
+	connStr := cfg.Database.URL()
+
+	migrator, err := outbound_repository.NewPostgresMigrator(connStr, postgresMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao preparar migrações: %w", err)
+	}
+	err = prepareSchema(migrator, cfg.Database.AutoMigrate)
+	migrator.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	dbPool, err := pgxpool.New(context.Background(), connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeouts.Database.Std()
+	return &repositories{
+		users:          outbound_repository.NewPostgresUserRepository(dbPool, timeout),
+		videos:         outbound_repository.NewPostgresVideoRepository(dbPool, timeout),
+		refreshTokens:  outbound_repository.NewPostgresRefreshTokenRepository(dbPool, timeout),
+		apiKeys:        outbound_repository.NewPostgresAPIKeyRepository(dbPool, timeout),
+		identities:     outbound_repository.NewPostgresUserIdentityRepository(dbPool, timeout),
+		accountTokens:  outbound_repository.NewPostgresAccountTokenRepository(dbPool, timeout),
+		loginThrottles: outbound_repository.NewPostgresLoginThrottleRepository(dbPool, timeout),
+		audit:          outbound_repository.NewPostgresAuditRepository(dbPool, timeout),
+		mfa:            outbound_repository.NewPostgresMFARepository(dbPool, timeout),
+		dataExports:    outbound_repository.NewPostgresDataExportRepository(dbPool, timeout),
+		organizations:  outbound_repository.NewPostgresOrganizationRepository(dbPool, timeout),
+		oidcStates:     outbound_repository.NewPostgresOIDCStateRepository(dbPool, timeout),
+		unitOfWork:     outbound_repository.NewPostgresUnitOfWork(dbPool),
+		health:         outbound_repository.NewPostgresHealthChecker(dbPool),
+		close:          dbPool.Close,
+	}, nil
+}
+
+// newSQLiteRepositories keeps users and videos in the SQLite file, checked against its own
+// migration set, and everything else in memory, which is why it is only allowed in dev mode
+func newSQLiteRepositories(cfg *config.Config) (*repositories, error) {
+	db, err := outbound_repository.OpenSQLite(cfg.Database.Path)
+	if err != nil {
+		return nil, err
+	}
+	// Closing the migrator would also close db, so it is dropped instead
+	migrator, err := outbound_repository.NewSQLiteMigrator(db, sqliteMigrations)
+	if err == nil {
+		err = prepareSchema(migrator, cfg.Database.AutoMigrate)
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	timeout := cfg.Timeouts.Database.Std()
+	repos := newMemoryRepositories()
+	repos.users = outbound_repository.NewSQLiteUserRepository(db, timeout)
+	repos.videos = outbound_repository.NewSQLiteVideoRepository(db, repos.organizations, timeout)
+	repos.unitOfWork = outbound_repository.NewSQLiteUnitOfWork(db)
+	repos.health = outbound_repository.NewSQLiteHealthChecker(db)
+	repos.close = func() { db.Close() }
+	return repos, nil
+}
+
+// prepareSchema applies pending migrations when auto-migrate is enabled. Otherwise a schema
+// that is behind or dirty stops the server instead of failing queries later.
+func prepareSchema(migrator *outbound_repository.Migrator, autoMigrate bool) error {
+	if autoMigrate {
+		if err := migrator.Up(0); err != nil {
+			return fmt.Errorf("erro ao aplicar migrações: %w", err)
+		}
+		fmt.Println("✅ Migrações aplicadas com sucesso!")
+		return nil
+	}
+
+	err := migrator.Check()
+	if errors.Is(err, outbound_repository.ErrSchemaDirty) {
+		return fmt.Errorf("%w. Corrija o banco manualmente e execute `video-processor migrate force <versão>`", err)
+	}
+	if err != nil {
+		return fmt.Errorf("%w. Execute `video-processor migrate up` ou defina DB_AUTO_MIGRATE=true", err)
+	}
+	if version, _, err := migrator.Version(); err == nil && version > migrator.Latest() {
+		log.Printf("⚠️ Schema do banco na versão %d, mais nova que a desta versão da aplicação (%d)", version, migrator.Latest())
+	}
+	return nil
+}
+
+// newMemoryRepositories shares one in-memory store between all repositories, so they
+// behave like tables of the same database
+func newMemoryRepositories() *repositories {
+	store := outbound_memory.NewStore()
+	return &repositories{
+		users:          outbound_memory.NewMemoryUserRepository(store),
+		videos:         outbound_memory.NewMemoryVideoRepository(store),
+		refreshTokens:  outbound_memory.NewMemoryRefreshTokenRepository(store),
+		apiKeys:        outbound_memory.NewMemoryAPIKeyRepository(store),
+		identities:     outbound_memory.NewMemoryUserIdentityRepository(store),
+		accountTokens:  outbound_memory.NewMemoryAccountTokenRepository(store),
+		loginThrottles: outbound_memory.NewMemoryLoginThrottleRepository(store),
+		audit:          outbound_memory.NewMemoryAuditRepository(store),
+		mfa:            outbound_memory.NewMemoryMFARepository(store),
+		dataExports:    outbound_memory.NewMemoryDataExportRepository(store),
+		organizations:  outbound_memory.NewMemoryOrganizationRepository(store),
+		oidcStates:     outbound_memory.NewMemoryOIDCStateRepository(store),
+		unitOfWork:     outbound_memory.NewMemoryUnitOfWork(store),
+		health:         ports.HealthCheckFunc(func(context.Context) error { return nil }),
+		close:          func() {},
+	}
+}
+
+// useTempStorage points the storage directories at a new temp dir, which the caller removes
+func useTempStorage(cfg *config.StorageConfig) (string, error) {
+	dir, err := os.MkdirTemp("", "video-processor-dev-")
+	if err != nil {
+		return "", err
+	}
+	cfg.UploadDir = filepath.Join(dir, "uploads")
+	cfg.OutputDir = filepath.Join(dir, "outputs")
+	cfg.TempDir = filepath.Join(dir, "temp")
+	return dir, nil
+}
+
+// runEvery calls task on every tick of period until ctx is cancelled.
+func runEvery(ctx context.Context, period time.Duration, task func()) {
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				task()
+			}
+		}
+	}()
+}
+
+// newTokenSigner builds the JWT signer from the jwt settings. Asymmetric keys (EdDSA by default)
+// are rotated automatically; HS256 with a shared secret remains available for legacy setups,
+// but the built-in default secret is only accepted in development mode.
+func newTokenSigner(ctx context.Context, cfg config.JWTConfig, devMode bool) (ports.TokenSigner, error) {
+	if cfg.Algorithm == outbound_signing.AlgorithmHS256 {
+		jwtSecret := cfg.Secret
+		if jwtSecret == "" || jwtSecret == outbound_signing.DefaultDevSecret {
+			if !devMode {
+				return nil, errors.New("JWT_SECRET ausente ou igual ao segredo padrão; defina um segredo forte ou use APP_ENV=development")
+			}
+			log.Printf("⚠️ Usando o segredo JWT padrão de desenvolvimento. Nunca use em produção!")
+			jwtSecret = outbound_signing.DefaultDevSecret
+		}
+		return outbound_signing.NewHMACSigner(jwtSecret), nil
+	}
+
+	// In-memory keys change on every restart and differ between replicas, so tokens issued
+	// by one instance would fail on another
+	if cfg.KeysDir == "" {
+		if !devMode {
+			return nil, errors.New("JWT_KEYS_DIR ausente; defina um diretório persistente, compartilhado entre as réplicas, ou use APP_ENV=development")
+		}
+		log.Printf("⚠️ JWT_KEYS_DIR não definido: chaves de assinatura apenas em memória. Nunca use em produção!")
+	}
+
+	keySet, err := outbound_signing.NewKeySet(outbound_signing.KeySetOptions{
+		Algorithm:        cfg.Algorithm,
+		Dir:              cfg.KeysDir,
+		RotationInterval: cfg.RotationInterval.Std(),
+		Retention:        cfg.KeyRetention.Std(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	keySet.StartRotation(ctx)
+	return keySet, nil
+}
+
+// newMailer sends through SMTP when a host is configured; otherwise emails are written to
+// the mail dir, or just logged, which is enough for local development.
+func newMailer(cfg config.MailConfig) (ports.Mailer, error) {
+	if cfg.SMTPHost == "" {
+		log.Printf("⚠️ SMTP_HOST não definido. E-mails não serão enviados, apenas registrados localmente.")
+		return outbound_mail.NewFileMailer(cfg.Dir, cfg.From)
+	}
+	return outbound_mail.NewSMTPMailer(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort), cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+}
+
+func newCORS(cfg config.CORSConfig) (gin.HandlerFunc, error) {
+	policy := inbound_http.DefaultCORSConfig()
+	if cfg.AllowedOrigins != nil {
+		policy.AllowedOrigins = cfg.AllowedOrigins
+	}
+	if cfg.AllowedMethods != nil {
+		policy.AllowedMethods = cfg.AllowedMethods
+	}
+	if cfg.AllowedHeaders != nil {
+		policy.AllowedHeaders = cfg.AllowedHeaders
+	}
+	if cfg.ExposedHeaders != nil {
+		policy.ExposedHeaders = cfg.ExposedHeaders
+	}
+	policy.AllowCredentials = cfg.AllowCredentials
+	policy.MaxAge = cfg.MaxAge.Std()
 
-	log.Fatal(r.Run(":8080"))
+	return inbound_http.CORS(policy)
 }
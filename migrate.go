@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	outbound_repository "video-processor/internal/adapters/outbound/repository"
+	"video-processor/internal/config"
+)
+
+// Migration sets, relative to the working directory like the rest of the paths
+const (
+	postgresMigrations = "migrations"
+	sqliteMigrations   = "migrations/sqlite"
+)
+
+const migrateUsage = `uso: video-processor migrate <comando>
+
+comandos:
+  up [N]          aplica as N próximas migrações (todas, sem N)
+  down [N|all]    reverte as N últimas migrações (uma, sem N)
+  goto V          migra para a versão V, aplicando ou revertendo migrações
+  version         mostra a versão atual do schema
+  force V         define a versão V sem executar migrações e limpa o estado dirty,
+                  depois de corrigir manualmente uma migração que falhou (-1: nenhuma)
+
+O banco é o mesmo configurado para a API (DB_DRIVER, DATABASE_URL, DB_*, SQLITE_PATH).`
+
+// runMigrate runs the migrate subcommand against the configured database
+func runMigrate(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Println(migrateUsage)
+		return nil
+	}
+	command, args := args[0], args[1:]
+
+	cfg, err := config.LoadDatabase()
+	if err != nil {
+		return err
+	}
+	migrator, err := newMigrator(cfg)
+	if err != nil {
+		return fmt.Errorf("erro ao preparar migrações: %w", err)
+	}
+	defer migrator.Close()
+
+	switch command {
+	case "up":
+		var steps int
+		if steps, err = optionalCount(args, 0); err != nil {
+			return err
+		}
+		err = migrator.Up(steps)
+	case "down":
+		steps := 1
+		if len(args) == 1 && args[0] == "all" {
+			steps = 0
+		} else if steps, err = optionalCount(args, 1); err != nil {
+			return err
+		}
+		err = migrator.Down(steps)
+	case "goto":
+		var version uint64
+		if len(args) != 1 {
+			return errors.New("uso: video-processor migrate goto V")
+		}
+		if version, err = strconv.ParseUint(args[0], 10, 0); err != nil {
+			return fmt.Errorf("versão inválida: %q", args[0])
+		}
+		err = migrator.Goto(uint(version))
+	case "force":
+		var version int
+		if len(args) != 1 {
+			return errors.New("uso: video-processor migrate force V")
+		}
+		if version, err = strconv.Atoi(args[0]); err != nil || version < -1 {
+			return fmt.Errorf("versão inválida: %q", args[0])
+		}
+		err = migrator.Force(version)
+	case "version":
+		if len(args) != 0 {
+			return errors.New("uso: video-processor migrate version")
+		}
+	default:
+		return fmt.Errorf("comando desconhecido: %q\n\n%s", command, migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	status := "✅"
+	if dirty {
+		status = "⚠️ (dirty)"
+	} else if version < migrator.Latest() {
+		status = "⚠️ (migrações pendentes)"
+	}
+	fmt.Printf("Versão do schema: %d de %d %s\n", version, migrator.Latest(), status)
+	return nil
+}
+
+// newMigrator opens the migration set of the configured driver
+func newMigrator(cfg *config.Config) (*outbound_repository.Migrator, error) {
+	if cfg.Database.Driver == config.DriverSQLite {
+		db, err := outbound_repository.OpenSQLite(cfg.Database.Path)
+		if err != nil {
+			return nil, err
+		}
+		migrator, err := outbound_repository.NewSQLiteMigrator(db, sqliteMigrations)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		return migrator, nil
+	}
+	return outbound_repository.NewPostgresMigrator(cfg.Database.URL(), postgresMigrations)
+}
+
+// optionalCount parses the optional positive count of up and down
+func optionalCount(args []string, fallback int) (int, error) {
+	switch len(args) {
+	case 0:
+		return fallback, nil
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("quantidade inválida: %q", args[0])
+		}
+		return n, nil
+	default:
+		return 0, errors.New("argumentos demais")
+	}
+}
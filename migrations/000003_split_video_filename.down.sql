@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_videos_storage_key;
+
+ALTER TABLE videos DROP COLUMN IF EXISTS original_filename;
+ALTER TABLE videos RENAME COLUMN storage_key TO filename;
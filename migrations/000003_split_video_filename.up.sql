@@ -0,0 +1,11 @@
+ALTER TABLE videos RENAME COLUMN filename TO storage_key;
+ALTER TABLE videos ADD COLUMN original_filename VARCHAR(255);
+
+-- Legacy rows stored "<date>_<time>_<nanos>_<original name>" as the filename
+UPDATE videos
+SET original_filename = regexp_replace(storage_key, '^[0-9]{8}_[0-9]{6}_[0-9]+_', '')
+WHERE original_filename IS NULL;
+
+ALTER TABLE videos ALTER COLUMN original_filename SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_videos_storage_key ON videos(storage_key);
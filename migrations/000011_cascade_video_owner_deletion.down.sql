@@ -0,0 +1,3 @@
+-- Accounts with videos can no longer be deleted
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_user_id_fkey;
+ALTER TABLE videos ADD CONSTRAINT videos_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
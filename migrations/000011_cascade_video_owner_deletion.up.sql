@@ -0,0 +1,3 @@
+-- Deleting an account removes its videos; the files are cleaned up by the application first
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_user_id_fkey;
+ALTER TABLE videos ADD CONSTRAINT videos_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
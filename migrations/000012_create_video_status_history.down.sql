@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS videos_status_history ON videos;
+DROP FUNCTION IF EXISTS record_video_status();
+
+DROP TABLE IF EXISTS video_status_history;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS video_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_video_status_history_video_id ON video_status_history(video_id);
+
+-- Recorded by the database so changes made by the worker are captured too
+CREATE OR REPLACE FUNCTION record_video_status() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.status IS DISTINCT FROM OLD.status THEN
+        INSERT INTO video_status_history (video_id, status, message) VALUES (NEW.id, NEW.status, NEW.message);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER videos_status_history
+    AFTER INSERT OR UPDATE OF status ON videos
+    FOR EACH ROW EXECUTE FUNCTION record_video_status();
+
+-- Existing videos start their history at their current status
+INSERT INTO video_status_history (video_id, status, message, created_at)
+SELECT id, status, message, updated_at FROM videos;
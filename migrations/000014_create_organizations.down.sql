@@ -0,0 +1,6 @@
+-- Videos shared with an organization stay with the user who uploaded them
+DROP INDEX IF EXISTS idx_videos_organization_id;
+ALTER TABLE videos DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
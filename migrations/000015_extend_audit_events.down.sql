@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS protect_audit_events();
+
+DROP INDEX IF EXISTS idx_audit_events_actor_id;
+DROP INDEX IF EXISTS idx_audit_events_action;
+
+ALTER TABLE audit_events
+    DROP COLUMN IF EXISTS request_id,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS outcome;
@@ -0,0 +1,6 @@
+ALTER TABLE videos
+    DROP COLUMN IF EXISTS output_size,
+    DROP COLUMN IF EXISTS worker_id,
+    DROP COLUMN IF EXISTS error_code,
+    DROP COLUMN IF EXISTS processing_finished_at,
+    DROP COLUMN IF EXISTS processing_started_at;
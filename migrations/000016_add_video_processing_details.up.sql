@@ -0,0 +1,6 @@
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS processing_started_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS processing_finished_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS error_code VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS worker_id VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS output_size BIGINT;
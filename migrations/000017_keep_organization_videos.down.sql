@@ -0,0 +1,6 @@
+-- Videos whose uploader is gone cannot be kept; their files are left in storage
+DELETE FROM videos WHERE user_id IS NULL;
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_owner_check;
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_user_id_fkey;
+ALTER TABLE videos ADD CONSTRAINT videos_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE videos ALTER COLUMN user_id SET NOT NULL;
@@ -0,0 +1,6 @@
+-- Organization videos outlive the account of who uploaded them, which then reads NULL;
+-- personal videos are still removed with the account, by the application first
+ALTER TABLE videos ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_user_id_fkey;
+ALTER TABLE videos ADD CONSTRAINT videos_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE videos ADD CONSTRAINT videos_owner_check CHECK (user_id IS NOT NULL OR organization_id IS NOT NULL);
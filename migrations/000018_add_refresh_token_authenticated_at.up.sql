@@ -0,0 +1,6 @@
+-- When the user logged in to start the session; existing families use their first token
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS authenticated_at TIMESTAMP WITH TIME ZONE;
+UPDATE refresh_tokens t SET authenticated_at = (
+    SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = t.family_id
+);
+ALTER TABLE refresh_tokens ALTER COLUMN authenticated_at SET NOT NULL;
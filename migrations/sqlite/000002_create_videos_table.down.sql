@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS videos_status_history_update;
+DROP TRIGGER IF EXISTS videos_status_history_insert;
+
+DROP TABLE IF EXISTS video_status_history;
+DROP TABLE IF EXISTS videos;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS videos (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- Organizations are not stored in SQLite, so there is no foreign key
+    organization_id INTEGER,
+    original_filename TEXT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    zip_path TEXT,
+    frame_count INTEGER DEFAULT 0,
+    message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_videos_user_id ON videos(user_id);
+CREATE INDEX idx_videos_organization_id ON videos(organization_id);
+
+CREATE TABLE IF NOT EXISTS video_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_video_status_history_video_id ON video_status_history(video_id);
+
+-- Same history as the Postgres trigger, stamped with the time of the change
+CREATE TRIGGER videos_status_history_insert
+    AFTER INSERT ON videos
+BEGIN
+    INSERT INTO video_status_history (video_id, status, message, created_at)
+    VALUES (NEW.id, NEW.status, NEW.message, NEW.updated_at);
+END;
+
+CREATE TRIGGER videos_status_history_update
+    AFTER UPDATE OF status ON videos
+    WHEN NEW.status IS NOT OLD.status
+BEGIN
+    INSERT INTO video_status_history (video_id, status, message, created_at)
+    VALUES (NEW.id, NEW.status, NEW.message, NEW.updated_at);
+END;
@@ -0,0 +1,5 @@
+ALTER TABLE videos DROP COLUMN output_size;
+ALTER TABLE videos DROP COLUMN worker_id;
+ALTER TABLE videos DROP COLUMN error_code;
+ALTER TABLE videos DROP COLUMN processing_finished_at;
+ALTER TABLE videos DROP COLUMN processing_started_at;
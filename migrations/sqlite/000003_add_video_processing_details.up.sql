@@ -0,0 +1,5 @@
+ALTER TABLE videos ADD COLUMN processing_started_at TIMESTAMP;
+ALTER TABLE videos ADD COLUMN processing_finished_at TIMESTAMP;
+ALTER TABLE videos ADD COLUMN error_code TEXT;
+ALTER TABLE videos ADD COLUMN worker_id TEXT;
+ALTER TABLE videos ADD COLUMN output_size INTEGER;
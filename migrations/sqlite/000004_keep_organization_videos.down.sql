@@ -0,0 +1,57 @@
+-- Videos whose uploader is gone cannot be kept; their files are left in storage
+DELETE FROM videos WHERE user_id IS NULL;
+-- Rebuilt the same way as in the up migration, with the previous foreign key
+
+CREATE TABLE videos_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- Organizations are not stored in SQLite, so there is no foreign key
+    organization_id INTEGER,
+    original_filename TEXT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    zip_path TEXT,
+    frame_count INTEGER DEFAULT 0,
+    message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    processing_started_at TIMESTAMP,
+    processing_finished_at TIMESTAMP,
+    error_code TEXT,
+    worker_id TEXT,
+    output_size INTEGER
+);
+
+INSERT INTO videos_new (id, user_id, organization_id, original_filename, storage_key, status, zip_path, frame_count, message,
+    created_at, updated_at, processing_started_at, processing_finished_at, error_code, worker_id, output_size)
+SELECT id, user_id, organization_id, original_filename, storage_key, status, zip_path, frame_count, message,
+    created_at, updated_at, processing_started_at, processing_finished_at, error_code, worker_id, output_size
+FROM videos;
+-- Ids of deleted videos are not handed out again
+DELETE FROM sqlite_sequence WHERE name = 'videos_new';
+INSERT INTO sqlite_sequence (name, seq) SELECT 'videos_new', seq FROM sqlite_sequence WHERE name = 'videos';
+
+CREATE TABLE video_status_history_old AS SELECT * FROM video_status_history;
+DROP TABLE videos;
+ALTER TABLE videos_new RENAME TO videos;
+INSERT INTO video_status_history (id, video_id, status, message, created_at)
+SELECT id, video_id, status, message, created_at FROM video_status_history_old;
+DROP TABLE video_status_history_old;
+
+CREATE INDEX idx_videos_user_id ON videos(user_id);
+CREATE INDEX idx_videos_organization_id ON videos(organization_id);
+
+CREATE TRIGGER videos_status_history_insert
+    AFTER INSERT ON videos
+BEGIN
+    INSERT INTO video_status_history (video_id, status, message, created_at)
+    VALUES (NEW.id, NEW.status, NEW.message, NEW.updated_at);
+END;
+
+CREATE TRIGGER videos_status_history_update
+    AFTER UPDATE OF status ON videos
+    WHEN NEW.status IS NOT OLD.status
+BEGIN
+    INSERT INTO video_status_history (video_id, status, message, created_at)
+    VALUES (NEW.id, NEW.status, NEW.message, NEW.updated_at);
+END;